@@ -0,0 +1,143 @@
+package appraisal
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/pequalsnp/go-eveonline/pkg/esi"
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+	"github.com/pequalsnp/go-eveonline/pkg/sde"
+)
+
+// TypeResolver maps item names to type ids.  Names are looked up ignoring case and surrounding
+// whitespace; names without a match are left out of the result.
+type TypeResolver interface {
+	ResolveTypeNames(names []string) (map[string]eveonline.TypeID, error)
+}
+
+type SDETypeResolver struct {
+	Types *sde.TypeStore
+}
+
+func (r SDETypeResolver) ResolveTypeNames(names []string) (map[string]eveonline.TypeID, error) {
+	typeIDs := make(map[string]eveonline.TypeID)
+	for _, name := range names {
+		if typeObj, ok := r.Types.TypeByName(name); ok {
+			typeIDs[normalizeName(name)] = typeObj.ID
+		}
+	}
+	return typeIDs, nil
+}
+
+// ESITypeResolver resolves names with the bulk /universe/ids/ endpoint.
+type ESITypeResolver struct {
+	ESI        *esi.ESI
+	HttpClient *http.Client
+}
+
+func (r ESITypeResolver) ResolveTypeNames(names []string) (map[string]eveonline.TypeID, error) {
+	ids, err := r.ESI.GetUniverseIDs(r.HttpClient, names)
+	if err != nil {
+		return nil, err
+	}
+
+	typeIDs := make(map[string]eveonline.TypeID)
+	for _, inventoryType := range ids.InventoryTypes {
+		typeIDs[normalizeName(inventoryType.Name)] = eveonline.TypeID(inventoryType.ID)
+	}
+	return typeIDs, nil
+}
+
+func normalizeName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// Prices are unit prices per type.  Any of the maps may be nil, leaving that price at zero.
+type Prices struct {
+	Buy     map[eveonline.TypeID]float64
+	Sell    map[eveonline.TypeID]float64
+	Average esi.AveragePrices
+}
+
+func PricesFromMarket(market *esi.Market, averages esi.AveragePrices) Prices {
+	return Prices{Buy: market.HighestBuys, Sell: market.LowestSells, Average: averages}
+}
+
+type Item struct {
+	TypeID eveonline.TypeID
+	// Name is spelled as it was first pasted.
+	Name     string
+	Quantity int64
+	// Unit prices.
+	Buy     float64
+	Sell    float64
+	Average float64
+}
+
+type Appraisal struct {
+	// Items are merged by type and kept in the order they first appear.
+	Items        []*Item
+	TotalBuy     float64
+	TotalSell    float64
+	TotalAverage float64
+	// Unparsed lists the lines that matched no format or named no known type.
+	Unparsed []string
+}
+
+// Appraise parses pasted inventory, contract, cargo scan, "Nx Name" and EFT text and values it.
+func Appraise(text string, resolver TypeResolver, prices Prices) (*Appraisal, error) {
+	lines := parseLines(text)
+
+	seen := make(map[string]bool)
+	names := make([]string, 0)
+	for _, line := range lines {
+		for _, candidate := range line.candidates {
+			key := normalizeName(candidate.name)
+			if !seen[key] {
+				seen[key] = true
+				names = append(names, candidate.name)
+			}
+		}
+	}
+	typeIDs, err := resolver.ResolveTypeNames(names)
+	if err != nil {
+		return nil, err
+	}
+
+	appraisal := &Appraisal{Items: make([]*Item, 0), Unparsed: make([]string, 0)}
+	items := make(map[eveonline.TypeID]*Item)
+	for _, line := range lines {
+		resolved := false
+		for _, candidate := range line.candidates {
+			typeID, ok := typeIDs[normalizeName(candidate.name)]
+			if !ok {
+				continue
+			}
+			item, ok := items[typeID]
+			if !ok {
+				item = &Item{
+					TypeID:  typeID,
+					Name:    candidate.name,
+					Buy:     prices.Buy[typeID],
+					Sell:    prices.Sell[typeID],
+					Average: prices.Average[typeID],
+				}
+				items[typeID] = item
+				appraisal.Items = append(appraisal.Items, item)
+			}
+			item.Quantity += candidate.quantity
+			resolved = true
+			break
+		}
+		if !resolved {
+			appraisal.Unparsed = append(appraisal.Unparsed, line.text)
+		}
+	}
+
+	for _, item := range appraisal.Items {
+		appraisal.TotalBuy += item.Buy * float64(item.Quantity)
+		appraisal.TotalSell += item.Sell * float64(item.Quantity)
+		appraisal.TotalAverage += item.Average * float64(item.Quantity)
+	}
+	return appraisal, nil
+}
@@ -0,0 +1,98 @@
+package appraisal
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/pequalsnp/go-eveonline/pkg/esi"
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+	"github.com/pequalsnp/go-eveonline/pkg/sde"
+	"github.com/stretchr/testify/assert"
+)
+
+func loadTestResolver(t *testing.T) SDETypeResolver {
+	contents, err := ioutil.ReadFile("../../test/testdata/typeIDs.yaml")
+	if err != nil {
+		t.Fatalf("Failed to read typeIDs YAML test data: %v", err)
+	}
+	types, err := sde.ImportTypes(contents)
+	assert.Nil(t, err)
+	return SDETypeResolver{Types: sde.NewTypeStore(types, nil, nil)}
+}
+
+var testPrices = Prices{
+	Buy:     map[eveonline.TypeID]float64{34: 4.0, 587: 400000},
+	Sell:    map[eveonline.TypeID]float64{34: 5.0, 587: 450000},
+	Average: esi.AveragePrices{34: 4.5, 2486: 2000},
+}
+
+func TestAppraiseFormats(t *testing.T) {
+	resolver := loadTestResolver(t)
+
+	tests := []struct {
+		name     string
+		text     string
+		expected map[eveonline.TypeID]int64
+	}{
+		{
+			name:     "inventory",
+			text:     "Tritanium\t1,000\tMineral\t\t\t10 m3\t5,000.00 ISK\nRifter\t\tFrigate\t\t\t27,289 m3",
+			expected: map[eveonline.TypeID]int64{34: 1000, 587: 1},
+		},
+		{
+			name:     "contract",
+			text:     "Pyerite\t250\tMineral\tMaterial\t\nWarrior I\t5\tCombat Drone\tDrone\t",
+			expected: map[eveonline.TypeID]int64{35: 250, 2486: 5},
+		},
+		{
+			name:     "quantity prefix",
+			text:     "10x Tritanium\n2 x Warrior I\n1.500x Pyerite",
+			expected: map[eveonline.TypeID]int64{34: 10, 2486: 2, 35: 1500},
+		},
+		{
+			name:     "cargo scan",
+			text:     "3 200mm AutoCannon I\n12000 Tritanium",
+			expected: map[eveonline.TypeID]int64{2873: 3, 34: 12000},
+		},
+		{
+			name: "eft",
+			text: "[Rifter, Tackle]\nDamage Control I\n[Empty Low slot]\n\n1MN Afterburner I\n\n" +
+				"200mm AutoCannon I, EMP S\n200mm AutoCannon I, EMP S\n\nWarrior I x3\nEMP S x200",
+			expected: map[eveonline.TypeID]int64{587: 1, 2046: 1, 439: 1, 2873: 2, 2486: 3, 185: 200},
+		},
+		{
+			name:     "eft blocks",
+			text:     "Tritanium\t100\n[Rifter, One]\nDamage Control I\n\n[Rifter, Two]\n[Empty Low slot]\nWarrior I x2",
+			expected: map[eveonline.TypeID]int64{34: 100, 587: 2, 2046: 1, 2486: 2},
+		},
+	}
+
+	for _, test := range tests {
+		appraisal, err := Appraise(test.text, resolver, testPrices)
+		assert.Nil(t, err, test.name)
+		assert.Empty(t, appraisal.Unparsed, test.name)
+		quantities := make(map[eveonline.TypeID]int64)
+		for _, item := range appraisal.Items {
+			quantities[item.TypeID] = item.Quantity
+		}
+		assert.Equal(t, test.expected, quantities, test.name)
+	}
+}
+
+func TestAppraiseValues(t *testing.T) {
+	appraisal, err := Appraise("Tritanium\t1,000\nRifter\n500 tritanium\nWarrior I x2\nNot An Item\nRifter\tlots", loadTestResolver(t), testPrices)
+	assert.Nil(t, err)
+
+	assert.Len(t, appraisal.Items, 3)
+	tritanium := appraisal.Items[0]
+	assert.Equal(t, "Tritanium", tritanium.Name)
+	assert.Equal(t, int64(1500), tritanium.Quantity)
+	assert.Equal(t, 4.0, tritanium.Buy)
+	assert.Equal(t, eveonline.TypeID(587), appraisal.Items[1].TypeID)
+	assert.Equal(t, 0.0, appraisal.Items[2].Sell)
+
+	assert.InDelta(t, 1500*4.0+400000, appraisal.TotalBuy, 0.0001)
+	assert.InDelta(t, 1500*5.0+450000, appraisal.TotalSell, 0.0001)
+	assert.InDelta(t, 1500*4.5+2*2000, appraisal.TotalAverage, 0.0001)
+	assert.Equal(t, []string{"Not An Item", "Rifter\tlots"}, appraisal.Unparsed)
+}
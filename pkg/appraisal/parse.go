@@ -0,0 +1,115 @@
+package appraisal
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pequalsnp/go-eveonline/pkg/fitting"
+)
+
+var quantityPrefixRegexp = regexp.MustCompile(`^(\d[\d,.' ]*?)\s*x\s+(\S.*)$`)
+var quantitySuffixRegexp = regexp.MustCompile(`^(\S.*?)\s+x\s*(\d[\d,.' ]*)$`)
+var cargoScanRegexp = regexp.MustCompile(`^(\d[\d,.']*)\s+(\S.*)$`)
+
+// candidate is one reading of a line.  A line usually has several, since "3 Rifter" could also be
+// a type called "3 Rifter", and the first whose name resolves wins.
+type candidate struct {
+	name     string
+	quantity int64
+}
+
+type parsedLine struct {
+	text       string
+	candidates []candidate
+}
+
+// parseLines reads each non-blank line of pasted text.  An EFT header starts a fit that runs to
+// the next header or the end of the text and is read by the fitting package.
+func parseLines(text string) []*parsedLine {
+	lines := make([]*parsedLine, 0)
+	rawLines := strings.Split(strings.Replace(text, "\r\n", "\n", -1), "\n")
+	for i := 0; i < len(rawLines); i++ {
+		line := strings.TrimSpace(rawLines[i])
+		if line == "" {
+			continue
+		}
+		if !fitting.IsEFTHeader(line) {
+			lines = append(lines, &parsedLine{text: line, candidates: lineCandidates(line)})
+			continue
+		}
+
+		end := i + 1
+		for end < len(rawLines) && !fitting.IsEFTHeader(rawLines[end]) {
+			end++
+		}
+		lines = append(lines, eftLines(line, strings.Join(rawLines[i:end], "\n"))...)
+		i = end - 1
+	}
+	return lines
+}
+
+// eftLines counts the ship and each module, drone and cargo line of a fit.  Loaded charges are
+// not counted, their quantity is not part of the fit.
+func eftLines(header string, text string) []*parsedLine {
+	eft, err := fitting.ScanEFT(text)
+	if err != nil {
+		return []*parsedLine{{text: header}}
+	}
+
+	lines := []*parsedLine{{text: header, candidates: []candidate{{name: eft.ShipName, quantity: 1}}}}
+	for _, line := range eft.Lines {
+		quantity := int64(1)
+		if line.Quantity > 0 {
+			quantity = int64(line.Quantity)
+		}
+		lines = append(lines, &parsedLine{text: line.Name, candidates: []candidate{{name: line.Name, quantity: quantity}}})
+	}
+	return lines
+}
+
+func lineCandidates(line string) []candidate {
+	// Inventory and contract listings are tab separated with the name first and the quantity,
+	// empty for unstackable items, second.
+	if strings.Contains(line, "\t") {
+		columns := strings.Split(line, "\t")
+		name := strings.TrimSpace(columns[0])
+		quantity := int64(1)
+		if len(columns) > 1 && strings.TrimSpace(columns[1]) != "" {
+			parsed, ok := parseQuantity(columns[1])
+			if !ok {
+				return nil
+			}
+			quantity = parsed
+		}
+		return []candidate{{name: name, quantity: quantity}}
+	}
+
+	candidates := []candidate{{name: line, quantity: 1}}
+	if match := quantityPrefixRegexp.FindStringSubmatch(line); match != nil {
+		if quantity, ok := parseQuantity(match[1]); ok {
+			candidates = append(candidates, candidate{name: match[2], quantity: quantity})
+		}
+	}
+	if match := quantitySuffixRegexp.FindStringSubmatch(line); match != nil {
+		if quantity, ok := parseQuantity(match[2]); ok {
+			candidates = append(candidates, candidate{name: match[1], quantity: quantity})
+		}
+	}
+	if match := cargoScanRegexp.FindStringSubmatch(line); match != nil {
+		if quantity, ok := parseQuantity(match[1]); ok {
+			candidates = append(candidates, candidate{name: match[2], quantity: quantity})
+		}
+	}
+	return candidates
+}
+
+// parseQuantity accepts the digit grouping the client uses in any language.
+func parseQuantity(text string) (int64, bool) {
+	digits := strings.NewReplacer(",", "", ".", "", "'", "", " ", "", "\u00a0", "").Replace(strings.TrimSpace(text))
+	quantity, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil || quantity <= 0 {
+		return 0, false
+	}
+	return quantity, true
+}
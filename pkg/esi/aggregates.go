@@ -0,0 +1,136 @@
+package esi
+
+import (
+	"math"
+	"sort"
+
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+)
+
+// The share of a side's volume, best prices first, averaged into Percentile.
+const AggregatePercentile = 0.05
+
+type MarketSideAggregate struct {
+	Min             float64
+	Max             float64
+	WeightedAverage float64
+	Median          float64
+	StdDev          float64
+	Percentile      float64
+	Volume          int64
+	OrderCount      int
+}
+
+type MarketAggregate struct {
+	TypeID eveonline.TypeID
+	Buy    MarketSideAggregate
+	Sell   MarketSideAggregate
+}
+
+// Aggregates summarises both sides of the book for every type.  Buy orders are kept when
+// buyFilter accepts them and sell orders when sellFilter does; nil filters accept everything.
+func (o *Orders) Aggregates(buyFilter OrderFilter, sellFilter OrderFilter) map[eveonline.TypeID]*MarketAggregate {
+	buys := make(map[eveonline.TypeID][]*Order)
+	sells := make(map[eveonline.TypeID][]*Order)
+	for _, order := range o.Orders {
+		if order.VolumeRemain <= 0 {
+			continue
+		}
+		if order.IsBuy {
+			if buyFilter == nil || buyFilter(order) {
+				buys[order.TypeID] = append(buys[order.TypeID], order)
+			}
+		} else if sellFilter == nil || sellFilter(order) {
+			sells[order.TypeID] = append(sells[order.TypeID], order)
+		}
+	}
+
+	aggregates := make(map[eveonline.TypeID]*MarketAggregate)
+	aggregate := func(typeID eveonline.TypeID) *MarketAggregate {
+		if _, ok := aggregates[typeID]; !ok {
+			aggregates[typeID] = &MarketAggregate{TypeID: typeID}
+		}
+		return aggregates[typeID]
+	}
+	for typeID, orders := range buys {
+		aggregate(typeID).Buy = aggregateSide(orders, true)
+	}
+	for typeID, orders := range sells {
+		aggregate(typeID).Sell = aggregateSide(orders, false)
+	}
+	return aggregates
+}
+
+// LocationAggregates prices a single station or structure: sell orders listed there and buy
+// orders whose range covers it.  See BuyOrderReaches for jumps.
+func (o *Orders) LocationAggregates(
+	locationID eveonline.LocationID,
+	systemID eveonline.SystemID,
+	jumps func(from eveonline.SystemID, to eveonline.SystemID) (int, bool),
+) map[eveonline.TypeID]*MarketAggregate {
+	return o.Aggregates(BuyOrderReaches(locationID, systemID, jumps), AtLocation(locationID))
+}
+
+// SystemAggregates prices a whole solar system: sell orders listed anywhere in it and buy orders
+// that can be filled from at least one of its stations.
+func (o *Orders) SystemAggregates(
+	systemID eveonline.SystemID,
+	jumps func(from eveonline.SystemID, to eveonline.SystemID) (int, bool),
+) map[eveonline.TypeID]*MarketAggregate {
+	return o.Aggregates(BuyOrderReachesSystem(systemID, jumps), InSystem(systemID))
+}
+
+// aggregateSide expects orders for a single type and side.  The median and standard deviation
+// are weighted by remaining volume.
+func aggregateSide(orders []*Order, isBuy bool) MarketSideAggregate {
+	sort.SliceStable(orders, func(i, j int) bool {
+		if isBuy {
+			return orders[i].Price > orders[j].Price
+		}
+		return orders[i].Price < orders[j].Price
+	})
+
+	side := MarketSideAggregate{Min: math.MaxFloat64, OrderCount: len(orders)}
+	var total float64
+	for _, order := range orders {
+		side.Min = math.Min(side.Min, order.Price)
+		side.Max = math.Max(side.Max, order.Price)
+		side.Volume += order.VolumeRemain
+		total += order.Price * float64(order.VolumeRemain)
+	}
+	side.WeightedAverage = total / float64(side.Volume)
+
+	var variance float64
+	for _, order := range orders {
+		deviation := order.Price - side.WeightedAverage
+		variance += deviation * deviation * float64(order.VolumeRemain)
+	}
+	side.StdDev = math.Sqrt(variance / float64(side.Volume))
+
+	var cumulative int64
+	for _, order := range orders {
+		cumulative += order.VolumeRemain
+		if float64(cumulative) >= float64(side.Volume)/2 {
+			side.Median = order.Price
+			break
+		}
+	}
+
+	percentileVolume := int64(math.Ceil(float64(side.Volume) * AggregatePercentile))
+	var filled int64
+	var percentileTotal float64
+	for _, order := range orders {
+		units := order.VolumeRemain
+		if filled+units > percentileVolume {
+			units = percentileVolume - filled
+		}
+		filled += units
+		percentileTotal += float64(units) * order.Price
+		if filled >= percentileVolume {
+			break
+		}
+	}
+	side.Percentile = percentileTotal / float64(filled)
+
+	return side
+}
@@ -0,0 +1,48 @@
+package esi
+
+import (
+	"testing"
+
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAggregates(t *testing.T) {
+	orders := testOrders()
+
+	aggregates := orders.Aggregates(nil, nil)
+	assert.Len(t, aggregates, 2)
+	sell := aggregates[34].Sell
+	assert.Equal(t, 4.5, sell.Min)
+	assert.Equal(t, 5.0, sell.Max)
+	assert.Equal(t, int64(175), sell.Volume)
+	assert.Equal(t, 3, sell.OrderCount)
+	assert.InDelta(t, 850.0/175.0, sell.WeightedAverage, 0.0001)
+	assert.Equal(t, 5.0, sell.Median)
+	assert.Equal(t, 4.5, sell.Percentile)
+	assert.Equal(t, 4.2, aggregates[34].Buy.Max)
+	assert.Equal(t, 0, aggregates[35].Buy.OrderCount)
+}
+
+func TestLocationAndSystemAggregates(t *testing.T) {
+	orders := testOrders()
+
+	jita := orders.LocationAggregates(60003760, 30000142, nil)
+	assert.Equal(t, 4.5, jita[34].Sell.Min)
+	assert.Equal(t, int64(150), jita[34].Sell.Volume)
+	assert.Equal(t, 1, jita[34].Buy.OrderCount)
+	assert.Equal(t, 4.0, jita[34].Buy.Max)
+
+	oneJump := func(from eveonline.SystemID, to eveonline.SystemID) (int, bool) {
+		return 1, true
+	}
+	jita = orders.LocationAggregates(60003760, 30000142, oneJump)
+	assert.Equal(t, 4.2, jita[34].Buy.Max)
+
+	perimeter := orders.SystemAggregates(30000144, oneJump)
+	assert.Equal(t, 5.0, perimeter[34].Sell.Min)
+	assert.Equal(t, 1, perimeter[34].Buy.OrderCount)
+	assert.Equal(t, 4.2, perimeter[34].Buy.Max)
+	_, ok := perimeter[35]
+	assert.False(t, ok)
+}
@@ -0,0 +1,73 @@
+package esi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+)
+
+type Blueprint struct {
+	ItemID             int64                `json:"item_id"`
+	TypeID             eveonline.TypeID     `json:"type_id"`
+	LocationID         eveonline.LocationID `json:"location_id"`
+	LocationFlag       string               `json:"location_flag"`
+	MaterialEfficiency int                  `json:"material_efficiency"`
+	TimeEfficiency     int                  `json:"time_efficiency"`
+	Quantity           int                  `json:"quantity"`
+	Runs               int                  `json:"runs"`
+}
+
+// ESI reports a quantity of -1 for a singleton original, -2 for a copy and the stack size for
+// stacked originals.  Runs is -1 for originals.
+func (b Blueprint) IsOriginal() bool {
+	return b.Quantity != -2
+}
+
+func (b Blueprint) IsCopy() bool {
+	return b.Quantity == -2
+}
+
+const CharacterBlueprintsURLPattern = "https://esi.evetech.net/v2/characters/%d/blueprints/"
+const CorporationBlueprintsURLPattern = "https://esi.evetech.net/v2/corporations/%d/blueprints/"
+
+func (e *ESI) GetCharacterBlueprints(authdClient *http.Client, characterID eveonline.CharacterID) ([]*Blueprint, error) {
+	blueprints, err := e.getBlueprints(authdClient, fmt.Sprintf(CharacterBlueprintsURLPattern, characterID))
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get blueprints for character id %d, %v", characterID, err)
+	}
+
+	return blueprints, nil
+}
+
+func (e *ESI) GetCorporationBlueprints(
+	authdClient *http.Client,
+	corporationID eveonline.CorporationID,
+) ([]*Blueprint, error) {
+	blueprints, err := e.getBlueprints(authdClient, fmt.Sprintf(CorporationBlueprintsURLPattern, corporationID))
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get blueprints for corporation id %d, %v", corporationID, err)
+	}
+
+	return blueprints, nil
+}
+
+func (e *ESI) getBlueprints(authdClient *http.Client, url string) ([]*Blueprint, error) {
+	allPages, err := e.GetAllPages(url, 1, map[string][]string{}, authdClient)
+	if err != nil {
+		return nil, err
+	}
+
+	blueprints := make([]*Blueprint, 0)
+	for _, page := range allPages {
+		pageBlueprints := make([]*Blueprint, 0)
+		err = json.Unmarshal(page.Body, &pageBlueprints)
+		if err != nil {
+			return nil, err
+		}
+		blueprints = append(blueprints, pageBlueprints...)
+	}
+
+	return blueprints, nil
+}
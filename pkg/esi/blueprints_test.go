@@ -0,0 +1,32 @@
+package esi
+
+import (
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCharacterBlueprints(t *testing.T) {
+	body, err := ioutil.ReadFile("../../test/testdata/characterBlueprints.json")
+	if err != nil {
+		t.Fatalf("Failed to read character blueprints test data: %v", err)
+	}
+	e := &ESI{Cache: noCache{}, HttpClient: &http.Client{Transport: &fileTransport{body: body}}}
+
+	blueprints, err := e.GetCharacterBlueprints(nil, 2112625428)
+	assert.Nil(t, err)
+	assert.Len(t, blueprints, 4)
+
+	// Singleton original, copy, then a stack of three originals.
+	assert.True(t, blueprints[0].IsOriginal())
+	assert.False(t, blueprints[0].IsCopy())
+	assert.Equal(t, -1, blueprints[0].Runs)
+	assert.False(t, blueprints[1].IsOriginal())
+	assert.True(t, blueprints[1].IsCopy())
+	assert.Equal(t, 5, blueprints[1].Runs)
+	assert.True(t, blueprints[2].IsOriginal())
+	assert.False(t, blueprints[2].IsCopy())
+	assert.Equal(t, 3, blueprints[2].Quantity)
+}
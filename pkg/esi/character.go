@@ -40,6 +40,7 @@ type CharacterSkills struct {
 type Asset struct {
 	IsBlueprintCopy bool                 `json:"is_blueprint_copy"`
 	IsSingleton     bool                 `json:""is_singleton`
+	ItemID          int64                `json:"item_id"`
 	LocationID      eveonline.LocationID `json:"location_id"`
 	LocationType    string               `json:"location_type"`
 	TypeID          eveonline.TypeID     `json:"type_id"`
@@ -50,10 +51,23 @@ type CharacterAssets struct {
 	Assets []*Asset
 }
 
+const (
+	AgentStanding          = "agent"
+	NPCCorporationStanding = "npc_corp"
+	FactionStanding        = "faction"
+)
+
+type Standing struct {
+	FromID   int64   `json:"from_id"`
+	FromType string  `json:"from_type"`
+	Standing float64 `json:"standing"`
+}
+
 const CharacterDetailsURLPattern = "https://esi.evetech.net/v4/characters/%d/"
 const CharacterPortraitsURLPattern = "https://esi.evetech.net/v2/characters/%d/portrait"
 const CharacterSkillsURLPattern = "https://esi.evetech.net/v4/characters/%d/skills"
 const CharacterAssetsURLPattern = "https://esi.evetech.net/v3/characters/%d/assets/"
+const CharacterStandingsURLPattern = "https://esi.evetech.net/v2/characters/%d/standings/"
 
 func (e *ESI) GetCharacterDetails(httpClient *http.Client, characterID eveonline.CharacterID) (*Character, error) {
 	url := fmt.Sprintf(CharacterDetailsURLPattern, characterID)
@@ -127,3 +141,19 @@ func (e *ESI) GetCharacterAssets(authdClient *http.Client, characterID eveonline
 
 	return &CharacterAssets{Assets: assets}, nil
 }
+
+func (e *ESI) GetCharacterStandings(authdClient *http.Client, characterID eveonline.CharacterID) ([]*Standing, error) {
+	url := fmt.Sprintf(CharacterStandingsURLPattern, characterID)
+	resp, err := e.GetFromESI(url, authdClient, map[string][]string{})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get standings for character id %d, %v", characterID, err)
+	}
+
+	standings := make([]*Standing, 0)
+	err = json.Unmarshal(resp.Body, &standings)
+	if err != nil {
+		return nil, fmt.Errorf("Failed while unmarshalling standings for character %d, %v", characterID, err)
+	}
+
+	return standings, nil
+}
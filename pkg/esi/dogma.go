@@ -0,0 +1,114 @@
+package esi
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+)
+
+type DogmaAttribute struct {
+	ID           eveonline.DogmaAttributeID `json:"attribute_id"`
+	Name         string                     `json:"name"`
+	DisplayName  string                     `json:"display_name"`
+	Description  string                     `json:"description"`
+	DefaultValue float64                    `json:"default_value"`
+	UnitID       int                        `json:"unit_id"`
+	HighIsGood   bool                       `json:"high_is_good"`
+	Stackable    bool                       `json:"stackable"`
+	Published    bool                       `json:"published"`
+}
+
+type DogmaEffect struct {
+	ID             eveonline.DogmaEffectID `json:"effect_id"`
+	Name           string                  `json:"name"`
+	DisplayName    string                  `json:"display_name"`
+	Description    string                  `json:"description"`
+	EffectCategory int                     `json:"effect_category"`
+	IsOffensive    bool                    `json:"is_offensive"`
+	IsAssistance   bool                    `json:"is_assistance"`
+	Published      bool                    `json:"published"`
+}
+
+type TypeDogmaAttribute struct {
+	AttributeID eveonline.DogmaAttributeID `json:"attribute_id"`
+	Value       float64                    `json:"value"`
+}
+
+type TypeDogmaEffect struct {
+	EffectID  eveonline.DogmaEffectID `json:"effect_id"`
+	IsDefault bool                    `json:"is_default"`
+}
+
+const DogmaAttributesURL = "https://esi.evetech.net/v1/dogma/attributes/"
+const DogmaAttributeURLPattern = "https://esi.evetech.net/v1/dogma/attributes/%d/"
+const DogmaEffectsURL = "https://esi.evetech.net/v1/dogma/effects/"
+const DogmaEffectURLPattern = "https://esi.evetech.net/v2/dogma/effects/%d/"
+
+func (e *ESI) GetDogmaAttributeIDs() ([]eveonline.DogmaAttributeID, error) {
+	resp, err := e.GetFromESI(DogmaAttributesURL, nil, map[string][]string{})
+	if err != nil {
+		return nil, err
+	}
+
+	attributeIDs := make([]eveonline.DogmaAttributeID, 0)
+	err = json.Unmarshal(resp.Body, &attributeIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return attributeIDs, nil
+}
+
+func (e *ESI) GetDogmaAttribute(attributeID eveonline.DogmaAttributeID) (*DogmaAttribute, error) {
+	resp, err := e.GetFromESI(
+		fmt.Sprintf(DogmaAttributeURLPattern, attributeID),
+		nil,
+		map[string][]string{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	attribute := new(DogmaAttribute)
+	err = json.Unmarshal(resp.Body, attribute)
+	if err != nil {
+		return nil, err
+	}
+
+	return attribute, nil
+}
+
+func (e *ESI) GetDogmaEffectIDs() ([]eveonline.DogmaEffectID, error) {
+	resp, err := e.GetFromESI(DogmaEffectsURL, nil, map[string][]string{})
+	if err != nil {
+		return nil, err
+	}
+
+	effectIDs := make([]eveonline.DogmaEffectID, 0)
+	err = json.Unmarshal(resp.Body, &effectIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return effectIDs, nil
+}
+
+func (e *ESI) GetDogmaEffect(effectID eveonline.DogmaEffectID) (*DogmaEffect, error) {
+	resp, err := e.GetFromESI(
+		fmt.Sprintf(DogmaEffectURLPattern, effectID),
+		nil,
+		map[string][]string{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	effect := new(DogmaEffect)
+	err = json.Unmarshal(resp.Body, effect)
+	if err != nil {
+		return nil, err
+	}
+
+	return effect, nil
+}
@@ -1,8 +1,11 @@
 package esi
 
 import (
+	"bytes"
 	"crypto/sha256"
+	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"sort"
@@ -65,6 +68,28 @@ type ResponsePage struct {
 	Headers            http.Header
 }
 
+// StatusError is returned for responses that are neither a success nor a not-modified
+// revalidation of a cached page.
+type StatusError struct {
+	URL        string
+	StatusCode int
+}
+
+func (e StatusError) Error() string {
+	return fmt.Sprintf("%s returned status %d", e.URL, e.StatusCode)
+}
+
+func (e StatusError) NotFound() bool {
+	return e.StatusCode == http.StatusNotFound
+}
+
+func checkStatus(url string, responsePage *ResponsePage) error {
+	if responsePage.ResponseStatusCode == http.StatusOK || responsePage.ResponseStatusCode == http.StatusNotModified {
+		return nil
+	}
+	return StatusError{URL: url, StatusCode: responsePage.ResponseStatusCode}
+}
+
 func (c CacheInfo) Expired() bool {
 	return c.ExpiresAt.Before(time.Now())
 }
@@ -130,6 +155,50 @@ func (e *ESI) GetFromESI(url string, httpClient *http.Client, queryParams map[st
 	}, nil
 }
 
+// SendToESI makes an uncached request such as a POST or DELETE, encoding body as JSON when it is
+// not nil.  Responses other than 2xx are returned as a StatusError.
+func (e *ESI) SendToESI(method string, url string, httpClient *http.Client, body interface{}) (*ResponsePage, error) {
+	if httpClient == nil {
+		httpClient = e.HttpClient
+	}
+
+	var requestBody io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		requestBody = bytes.NewReader(encoded)
+	}
+	request, err := http.NewRequest(method, url, requestBody)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, StatusError{URL: url, StatusCode: resp.StatusCode}
+	}
+
+	return &ResponsePage{
+		Body:               responseBody,
+		ResponseStatusCode: resp.StatusCode,
+		Headers:            resp.Header,
+	}, nil
+}
+
 func (e *ESI) ScanPages(url string, httpClient *http.Client, scanFn func(*ResponsePage) (bool, error)) error {
 	page := 1
 	for {
@@ -0,0 +1,72 @@
+package esi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+)
+
+type FittingItem struct {
+	Flag     string           `json:"flag"`
+	Quantity int              `json:"quantity"`
+	TypeID   eveonline.TypeID `json:"type_id"`
+}
+
+type Fitting struct {
+	ID          int64            `json:"fitting_id,omitempty"`
+	Name        string           `json:"name"`
+	Description string           `json:"description"`
+	ShipTypeID  eveonline.TypeID `json:"ship_type_id"`
+	Items       []*FittingItem   `json:"items"`
+}
+
+const CharacterFittingsURLPattern = "https://esi.evetech.net/v2/characters/%d/fittings/"
+const CharacterFittingURLPattern = "https://esi.evetech.net/v1/characters/%d/fittings/%d/"
+
+func (e *ESI) GetCharacterFittings(authdClient *http.Client, characterID eveonline.CharacterID) ([]*Fitting, error) {
+	url := fmt.Sprintf(CharacterFittingsURLPattern, characterID)
+	resp, err := e.GetFromESI(url, authdClient, map[string][]string{})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get fittings for character id %d, %v", characterID, err)
+	}
+
+	fittings := make([]*Fitting, 0)
+	err = json.Unmarshal(resp.Body, &fittings)
+	if err != nil {
+		return nil, fmt.Errorf("Failed while unmarshalling fittings for character %d, %v", characterID, err)
+	}
+
+	return fittings, nil
+}
+
+// CreateCharacterFitting saves a fitting and returns the id ESI assigned to it.  The fitting's own
+// ID is ignored.
+func (e *ESI) CreateCharacterFitting(authdClient *http.Client, characterID eveonline.CharacterID, fitting *Fitting) (int64, error) {
+	newFitting := *fitting
+	newFitting.ID = 0
+	resp, err := e.SendToESI(http.MethodPost, fmt.Sprintf(CharacterFittingsURLPattern, characterID), authdClient, &newFitting)
+	if err != nil {
+		return 0, fmt.Errorf("Failed to create fitting for character id %d, %v", characterID, err)
+	}
+
+	created := struct {
+		ID int64 `json:"fitting_id"`
+	}{}
+	err = json.Unmarshal(resp.Body, &created)
+	if err != nil {
+		return 0, fmt.Errorf("Failed while unmarshalling created fitting for character %d, %v", characterID, err)
+	}
+
+	return created.ID, nil
+}
+
+func (e *ESI) DeleteCharacterFitting(authdClient *http.Client, characterID eveonline.CharacterID, fittingID int64) error {
+	_, err := e.SendToESI(http.MethodDelete, fmt.Sprintf(CharacterFittingURLPattern, characterID, fittingID), authdClient, nil)
+	if err != nil {
+		return fmt.Errorf("Failed to delete fitting %d for character id %d, %v", fittingID, characterID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package esi
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateAndDeleteCharacterFitting(t *testing.T) {
+	transport := &fileTransport{body: []byte(`{"fitting_id": 1234}`)}
+	e := &ESI{Cache: noCache{}, HttpClient: &http.Client{Transport: transport}}
+
+	fitting := &Fitting{
+		ID:         99,
+		Name:       "Tackle",
+		ShipTypeID: 587,
+		Items:      []*FittingItem{{Flag: "LoSlot0", Quantity: 1, TypeID: 2046}},
+	}
+	fittingID, err := e.CreateCharacterFitting(nil, 2112625428, fitting)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1234), fittingID)
+	assert.Equal(t, int64(99), fitting.ID)
+
+	request := transport.requests[0]
+	assert.Equal(t, http.MethodPost, request.Method)
+	assert.Equal(t, "https://esi.evetech.net/v2/characters/2112625428/fittings/", request.URL.String())
+	body, err := ioutil.ReadAll(request.Body)
+	assert.Nil(t, err)
+	sent := new(Fitting)
+	assert.Nil(t, json.Unmarshal(body, sent))
+	assert.Equal(t, int64(0), sent.ID)
+	assert.Equal(t, fitting.Items, sent.Items)
+
+	err = e.DeleteCharacterFitting(nil, 2112625428, 1234)
+	assert.Nil(t, err)
+	assert.Equal(t, http.MethodDelete, transport.requests[1].Method)
+	assert.Equal(t, "https://esi.evetech.net/v1/characters/2112625428/fittings/1234/", transport.requests[1].URL.String())
+}
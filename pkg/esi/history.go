@@ -0,0 +1,262 @@
+package esi
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+)
+
+const MarketHistoryURLPattern = "https://esi.evetech.net/v1/markets/%d/history/"
+
+const marketHistoryDateLayout = "2006-01-02"
+
+type MarketHistoryDay struct {
+	Date       time.Time
+	Average    float64
+	Highest    float64
+	Lowest     float64
+	Volume     int64
+	OrderCount int64
+}
+
+type esiMarketHistoryDay struct {
+	Date       string  `json:"date"`
+	Average    float64 `json:"average"`
+	Highest    float64 `json:"highest"`
+	Lowest     float64 `json:"lowest"`
+	Volume     int64   `json:"volume"`
+	OrderCount int64   `json:"order_count"`
+}
+
+// MarketHistory holds one entry per day with trades, oldest first.  ESI leaves out days without
+// any trades.
+type MarketHistory struct {
+	RegionID  eveonline.RegionID
+	TypeID    eveonline.TypeID
+	Days      []*MarketHistoryDay
+	ExpiresAt time.Time
+}
+
+func (e *ESI) GetMarketHistory(httpClient *http.Client, regionID eveonline.RegionID, typeID eveonline.TypeID) (*MarketHistory, error) {
+	url := fmt.Sprintf(MarketHistoryURLPattern, regionID)
+	resp, err := e.GetFromESI(url, httpClient, map[string][]string{"type_id": []string{fmt.Sprintf("%d", typeID)}})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get market history for type %d in region %d, %v", typeID, regionID, err)
+	}
+	if resp.ResponseStatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Failed to get market history for type %d in region %d, status %d", typeID, regionID, resp.ResponseStatusCode)
+	}
+
+	esiDays := make([]*esiMarketHistoryDay, 0)
+	err = json.Unmarshal(resp.Body, &esiDays)
+	if err != nil {
+		return nil, fmt.Errorf("Failed while unmarshalling market history for type %d in region %d, %v", typeID, regionID, err)
+	}
+
+	history := &MarketHistory{RegionID: regionID, TypeID: typeID, Days: make([]*MarketHistoryDay, 0, len(esiDays)), ExpiresAt: resp.ExpiresAt}
+	for _, esiDay := range esiDays {
+		date, err := time.Parse(marketHistoryDateLayout, esiDay.Date)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to parse market history date %s, %v", esiDay.Date, err)
+		}
+		history.Days = append(history.Days, &MarketHistoryDay{
+			Date:       date,
+			Average:    esiDay.Average,
+			Highest:    esiDay.Highest,
+			Lowest:     esiDay.Lowest,
+			Volume:     esiDay.Volume,
+			OrderCount: esiDay.OrderCount,
+		})
+	}
+	sort.Slice(history.Days, func(i, j int) bool { return history.Days[i].Date.Before(history.Days[j].Date) })
+
+	return history, nil
+}
+
+// GetMarketHistories fetches history for many types with at most concurrency requests in flight.
+// The first error stops new requests from being started and is returned.
+func (e *ESI) GetMarketHistories(
+	httpClient *http.Client,
+	regionID eveonline.RegionID,
+	typeIDs []eveonline.TypeID,
+	concurrency int,
+) (map[eveonline.TypeID]*MarketHistory, error) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	var firstErr error
+	histories := make(map[eveonline.TypeID]*MarketHistory)
+	semaphore := make(chan struct{}, concurrency)
+
+	for _, typeID := range typeIDs {
+		mutex.Lock()
+		failed := firstErr != nil
+		mutex.Unlock()
+		if failed {
+			break
+		}
+
+		semaphore <- struct{}{}
+		wg.Add(1)
+		go func(typeID eveonline.TypeID) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			history, err := e.GetMarketHistory(httpClient, regionID, typeID)
+			mutex.Lock()
+			defer mutex.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				return
+			}
+			histories[typeID] = history
+		}(typeID)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return histories, nil
+}
+
+type TimeSeriesPoint struct {
+	Date  time.Time
+	Value float64
+}
+
+// TimeSeries is ordered oldest first.
+type TimeSeries []TimeSeriesPoint
+
+func (h *MarketHistory) series(value func(day *MarketHistoryDay) float64) TimeSeries {
+	series := make(TimeSeries, 0, len(h.Days))
+	for _, day := range h.Days {
+		series = append(series, TimeSeriesPoint{Date: day.Date, Value: value(day)})
+	}
+	return series
+}
+
+func (h *MarketHistory) AveragePrices() TimeSeries {
+	return h.series(func(day *MarketHistoryDay) float64 { return day.Average })
+}
+
+func (h *MarketHistory) Volumes() TimeSeries {
+	return h.series(func(day *MarketHistoryDay) float64 { return float64(day.Volume) })
+}
+
+// AverageDailyVolume averages traded volume over the last days calendar days up to the most recent
+// entry, counting days missing from the history as zero.
+func (h *MarketHistory) AverageDailyVolume(days int) float64 {
+	if len(h.Days) == 0 || days < 1 {
+		return 0.0
+	}
+	last := h.Days[len(h.Days)-1].Date
+	since := last.AddDate(0, 0, -days)
+
+	var volume int64
+	for _, day := range h.Days {
+		if day.Date.After(since) {
+			volume += day.Volume
+		}
+	}
+	return float64(volume) / float64(days)
+}
+
+// Last returns the most recent n points, or the whole series when it is shorter.
+func (s TimeSeries) Last(n int) TimeSeries {
+	if n >= len(s) {
+		return s
+	}
+	return s[len(s)-n:]
+}
+
+func (s TimeSeries) Mean() float64 {
+	if len(s) == 0 {
+		return 0.0
+	}
+	var total float64
+	for _, point := range s {
+		total += point.Value
+	}
+	return total / float64(len(s))
+}
+
+// MovingAverage returns the simple moving average over window points.  The result starts at the
+// first point with a full window behind it.
+func (s TimeSeries) MovingAverage(window int) TimeSeries {
+	averages := make(TimeSeries, 0)
+	if window < 1 {
+		return averages
+	}
+	var total float64
+	for i, point := range s {
+		total += point.Value
+		if i >= window {
+			total -= s[i-window].Value
+		}
+		if i >= window-1 {
+			averages = append(averages, TimeSeriesPoint{Date: point.Date, Value: total / float64(window)})
+		}
+	}
+	return averages
+}
+
+// Volatility is the standard deviation of the daily log returns over the last window points.
+func (s TimeSeries) Volatility(window int) float64 {
+	points := s.Last(window + 1)
+	returns := make(TimeSeries, 0, len(points))
+	for i := 1; i < len(points); i++ {
+		if points[i-1].Value <= 0 || points[i].Value <= 0 {
+			continue
+		}
+		returns = append(returns, TimeSeriesPoint{Date: points[i].Date, Value: math.Log(points[i].Value / points[i-1].Value)})
+	}
+	if len(returns) < 2 {
+		return 0.0
+	}
+
+	mean := returns.Mean()
+	var variance float64
+	for _, point := range returns {
+		variance += (point.Value - mean) * (point.Value - mean)
+	}
+	return math.Sqrt(variance / float64(len(returns)-1))
+}
+
+// Trend fits a least squares line through the last window points and returns its slope per day as
+// a fraction of the mean, so 0.01 means prices rising about 1% a day.
+func (s TimeSeries) Trend(window int) float64 {
+	points := s.Last(window)
+	if len(points) < 2 {
+		return 0.0
+	}
+
+	start := points[0].Date
+	var sumX, sumY, sumXY, sumXX float64
+	for _, point := range points {
+		x := point.Date.Sub(start).Hours() / 24
+		sumX += x
+		sumY += point.Value
+		sumXY += x * point.Value
+		sumXX += x * x
+	}
+	n := float64(len(points))
+	denominator := n*sumXX - sumX*sumX
+	mean := sumY / n
+	if denominator == 0 || mean == 0 {
+		return 0.0
+	}
+	slope := (n*sumXY - sumX*sumY) / denominator
+	return slope / mean
+}
@@ -0,0 +1,74 @@
+package esi
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+	"github.com/stretchr/testify/assert"
+)
+
+type noCache struct{}
+
+func (noCache) Put(key []byte, responsePage *ResponsePage) error { return nil }
+func (noCache) Get(key []byte) (*ResponsePage, error)            { return nil, nil }
+
+type fileTransport struct {
+	body     []byte
+	mutex    sync.Mutex
+	requests []*http.Request
+}
+
+func (f *fileTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	f.mutex.Lock()
+	f.requests = append(f.requests, request)
+	f.mutex.Unlock()
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+		Body:       ioutil.NopCloser(bytes.NewReader(f.body)),
+		Request:    request,
+	}, nil
+}
+
+func TestGetMarketHistory(t *testing.T) {
+	body, err := ioutil.ReadFile("../../test/testdata/marketHistory.json")
+	if err != nil {
+		t.Fatalf("Failed to read market history test data: %v", err)
+	}
+	transport := &fileTransport{body: body}
+	e := &ESI{Cache: noCache{}, HttpClient: &http.Client{Transport: transport}}
+
+	history, err := e.GetMarketHistory(nil, 10000002, 34)
+	assert.Nil(t, err)
+	assert.Equal(t, "34", transport.requests[0].URL.Query().Get("type_id"))
+	assert.Len(t, history.Days, 3)
+	assert.Equal(t, time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), history.Days[0].Date)
+	assert.Equal(t, int64(1100), history.Days[0].OrderCount)
+
+	// The 2nd has no trades, so four calendar days hold three entries.
+	assert.InDelta(t, 12000000000.0/4, history.AverageDailyVolume(4), 0.0001)
+
+	histories, err := e.GetMarketHistories(nil, 10000002, []eveonline.TypeID{34, 35, 36}, 2)
+	assert.Nil(t, err)
+	assert.Len(t, histories, 3)
+}
+
+func TestTimeSeriesIndicators(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 10, d, 0, 0, 0, 0, time.UTC) }
+	series := TimeSeries{{day(1), 10}, {day(2), 11}, {day(3), 12}, {day(4), 13}}
+
+	assert.Equal(t, TimeSeries{{day(2), 10.5}, {day(3), 11.5}, {day(4), 12.5}}, series.MovingAverage(2))
+	assert.Empty(t, series.MovingAverage(5))
+	assert.Equal(t, TimeSeries{{day(3), 12}, {day(4), 13}}, series.Last(2))
+	assert.InDelta(t, 1.0/11.5, series.Trend(4), 0.0001)
+	assert.True(t, series.Volatility(3) > 0)
+
+	flat := TimeSeries{{day(1), 10}, {day(2), 10}, {day(3), 10}}
+	assert.Equal(t, 0.0, flat.Volatility(3))
+	assert.Equal(t, 0.0, flat.Trend(3))
+}
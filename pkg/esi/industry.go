@@ -0,0 +1,204 @@
+package esi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+)
+
+type IndustryJobStatus string
+
+const (
+	IndustryJobStatusActive    = IndustryJobStatus("active")
+	IndustryJobStatusCancelled = IndustryJobStatus("cancelled")
+	IndustryJobStatusDelivered = IndustryJobStatus("delivered")
+	IndustryJobStatusPaused    = IndustryJobStatus("paused")
+	IndustryJobStatusReady     = IndustryJobStatus("ready")
+	IndustryJobStatusReverted  = IndustryJobStatus("reverted")
+)
+
+type IndustryJob struct {
+	ID                  int64                        `json:"job_id"`
+	ActivityID          eveonline.IndustryActivityID `json:"activity_id"`
+	BlueprintID         int64                        `json:"blueprint_id"`
+	BlueprintTypeID     eveonline.TypeID             `json:"blueprint_type_id"`
+	BlueprintLocationID eveonline.LocationID         `json:"blueprint_location_id"`
+	ProductTypeID       eveonline.TypeID             `json:"product_type_id"`
+	Runs                int                          `json:"runs"`
+	LicensedRuns        int                          `json:"licensed_runs"`
+	SuccessfulRuns      int                          `json:"successful_runs"`
+	Probability         float64                      `json:"probability"`
+	Status              IndustryJobStatus            `json:"status"`
+	FacilityID          eveonline.LocationID         `json:"facility_id"`
+	LocationID          eveonline.LocationID         `json:"location_id"`
+	StationID           eveonline.LocationID         `json:"station_id,omitempty"`
+	OutputLocationID    eveonline.LocationID         `json:"output_location_id"`
+	InstallerID         eveonline.CharacterID        `json:"installer_id"`
+	CompletedByID       eveonline.CharacterID        `json:"completed_character_id"`
+	Cost                float64                      `json:"cost"`
+	DurationInSeconds   int                          `json:"duration"`
+	StartDate           time.Time                    `json:"start_date"`
+	EndDate             time.Time                    `json:"end_date"`
+	PauseDate           *time.Time                   `json:"pause_date,omitempty"`
+	CompletedDate       *time.Time                   `json:"completed_date,omitempty"`
+}
+
+type IndustryJobs []*IndustryJob
+
+func (jobs IndustryJobs) FilterByStatus(statuses ...IndustryJobStatus) IndustryJobs {
+	filtered := make(IndustryJobs, 0, len(jobs))
+	for _, job := range jobs {
+		for _, status := range statuses {
+			if job.Status == status {
+				filtered = append(filtered, job)
+				break
+			}
+		}
+	}
+
+	return filtered
+}
+
+const CharacterIndustryJobsURLPattern = "https://esi.evetech.net/v1/characters/%d/industry/jobs/"
+const CorporationIndustryJobsURLPattern = "https://esi.evetech.net/v1/corporations/%d/industry/jobs/"
+
+func (e *ESI) GetCharacterIndustryJobs(
+	authdClient *http.Client,
+	characterID eveonline.CharacterID,
+	includeCompleted bool,
+) (IndustryJobs, error) {
+	url := fmt.Sprintf(CharacterIndustryJobsURLPattern, characterID)
+	resp, err := e.GetFromESI(
+		url,
+		authdClient,
+		map[string][]string{"include_completed": []string{strconv.FormatBool(includeCompleted)}},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get industry jobs for character id %d, %v", characterID, err)
+	}
+
+	jobs := make(IndustryJobs, 0)
+	err = json.Unmarshal(resp.Body, &jobs)
+	if err != nil {
+		return nil, fmt.Errorf("Failed while unmarshalling industry jobs for character %d, %v", characterID, err)
+	}
+
+	for _, job := range jobs {
+		job.LocationID = job.StationID
+	}
+
+	return jobs, nil
+}
+
+func (e *ESI) GetCorporationIndustryJobs(
+	authdClient *http.Client,
+	corporationID eveonline.CorporationID,
+	includeCompleted bool,
+) (IndustryJobs, error) {
+	url := fmt.Sprintf(CorporationIndustryJobsURLPattern, corporationID)
+	allPages, err := e.GetAllPages(
+		url,
+		1,
+		map[string][]string{"include_completed": []string{strconv.FormatBool(includeCompleted)}},
+		authdClient,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get industry jobs for corporation id %d, %v", corporationID, err)
+	}
+
+	jobs := make(IndustryJobs, 0)
+	for _, page := range allPages {
+		pageJobs := make(IndustryJobs, 0)
+		err = json.Unmarshal(page.Body, &pageJobs)
+		if err != nil {
+			return nil, fmt.Errorf("Failed while unmarshalling industry jobs for corporation %d, %v", corporationID, err)
+		}
+		jobs = append(jobs, pageJobs...)
+	}
+
+	return jobs, nil
+}
+
+type IndustryFacility struct {
+	ID       eveonline.LocationID    `json:"facility_id"`
+	OwnerID  eveonline.CorporationID `json:"owner_id"`
+	RegionID eveonline.RegionID      `json:"region_id"`
+	SystemID eveonline.SystemID      `json:"solar_system_id"`
+	Tax      float64                 `json:"tax"`
+	TypeID   eveonline.TypeID        `json:"type_id"`
+}
+
+type esiIndustryCostIndex struct {
+	Activity  string  `json:"activity"`
+	CostIndex float64 `json:"cost_index"`
+}
+
+type esiIndustrySystem struct {
+	SystemID    eveonline.SystemID     `json:"solar_system_id"`
+	CostIndices []esiIndustryCostIndex `json:"cost_indices"`
+}
+
+var industryActivityNames = map[string]eveonline.IndustryActivityID{
+	"manufacturing":                   eveonline.ManufacturingActivityID,
+	"researching_time_efficiency":     eveonline.ResearchTimeEfficiencyActivityID,
+	"researching_material_efficiency": eveonline.ResearchMaterialEfficiencyActivityID,
+	"copying":                         eveonline.CopyingActivityID,
+	"invention":                       eveonline.InventionActivityID,
+	"reaction":                        eveonline.ReactionActivityID,
+}
+
+type IndustryCostIndices map[eveonline.SystemID]map[eveonline.IndustryActivityID]float64
+
+func (c IndustryCostIndices) CostIndex(systemID eveonline.SystemID, activityID eveonline.IndustryActivityID) float64 {
+	return c[systemID][activityID]
+}
+
+const IndustrySystemsURL = "https://esi.evetech.net/v1/industry/systems/"
+const IndustryFacilitiesURL = "https://esi.evetech.net/v1/industry/facilities/"
+
+func (e *ESI) GetIndustryCostIndices(httpClient *http.Client) (IndustryCostIndices, error) {
+	resp, err := e.GetFromESI(IndustrySystemsURL, httpClient, map[string][]string{})
+	if err != nil {
+		return nil, err
+	}
+
+	systems := make([]*esiIndustrySystem, 0)
+	err = json.Unmarshal(resp.Body, &systems)
+	if err != nil {
+		return nil, err
+	}
+
+	costIndices := make(IndustryCostIndices)
+	for _, system := range systems {
+		systemIndices := make(map[eveonline.IndustryActivityID]float64)
+		for _, costIndex := range system.CostIndices {
+			activityID, ok := industryActivityNames[costIndex.Activity]
+			if !ok {
+				continue
+			}
+			systemIndices[activityID] = costIndex.CostIndex
+		}
+		costIndices[system.SystemID] = systemIndices
+	}
+
+	return costIndices, nil
+}
+
+func (e *ESI) GetIndustryFacilities(httpClient *http.Client) ([]*IndustryFacility, error) {
+	resp, err := e.GetFromESI(IndustryFacilitiesURL, httpClient, map[string][]string{})
+	if err != nil {
+		return nil, err
+	}
+
+	facilities := make([]*IndustryFacility, 0)
+	err = json.Unmarshal(resp.Body, &facilities)
+	if err != nil {
+		return nil, err
+	}
+
+	return facilities, nil
+}
@@ -0,0 +1,34 @@
+package esi
+
+import (
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCharacterIndustryJobs(t *testing.T) {
+	body, err := ioutil.ReadFile("../../test/testdata/industryJobs.json")
+	if err != nil {
+		t.Fatalf("Failed to read industry jobs test data: %v", err)
+	}
+	transport := &fileTransport{body: body}
+	e := &ESI{Cache: noCache{}, HttpClient: &http.Client{Transport: transport}}
+
+	jobs, err := e.GetCharacterIndustryJobs(nil, 2112625428, true)
+	assert.Nil(t, err)
+	assert.Len(t, jobs, 4)
+	assert.Equal(t, "true", transport.requests[0].URL.Query().Get("include_completed"))
+	assert.Equal(t, eveonline.LocationID(60003760), jobs[0].LocationID)
+	assert.Equal(t, eveonline.InventionActivityID, jobs[1].ActivityID)
+	assert.NotNil(t, jobs[1].PauseDate)
+
+	running := jobs.FilterByStatus(IndustryJobStatusActive, IndustryJobStatusPaused)
+	assert.Len(t, running, 2)
+	assert.Equal(t, int64(229136101), running[0].ID)
+	assert.Equal(t, int64(229136102), running[1].ID)
+	assert.Empty(t, jobs.FilterByStatus(IndustryJobStatusCancelled))
+	assert.Empty(t, jobs.FilterByStatus())
+}
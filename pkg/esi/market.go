@@ -3,7 +3,6 @@ package esi
 import (
 	"encoding/json"
 	"fmt"
-	"math"
 	"net/http"
 	"time"
 
@@ -11,11 +10,27 @@ import (
 )
 
 type Order struct {
-	ID         int64                `json:"order_id"`
-	IsBuy      bool                 `json:"is_buy_order"`
-	LocationID eveonline.LocationID `json:"location_id"`
-	Price      float64              `json:"price"`
-	TypeID     eveonline.TypeID     `json:"type_id"`
+	ID           int64                `json:"order_id"`
+	IsBuy        bool                 `json:"is_buy_order"`
+	LocationID   eveonline.LocationID `json:"location_id"`
+	SystemID     eveonline.SystemID   `json:"system_id"`
+	Price        float64              `json:"price"`
+	TypeID       eveonline.TypeID     `json:"type_id"`
+	VolumeRemain int64                `json:"volume_remain"`
+	VolumeTotal  int64                `json:"volume_total"`
+	MinVolume    int64                `json:"min_volume"`
+	Range        string               `json:"range"`
+	Duration     int                  `json:"duration"`
+	Issued       time.Time            `json:"issued"`
+}
+
+func (o *Order) ExpiresAt() time.Time {
+	return o.Issued.AddDate(0, 0, o.Duration)
+}
+
+// Age is how long ago the order was issued or last modified; ESI resets issued on price changes.
+func (o *Order) Age(now time.Time) time.Duration {
+	return now.Sub(o.Issued)
 }
 
 type Orders struct {
@@ -24,65 +39,84 @@ type Orders struct {
 	ExpiresAt time.Time
 }
 
+// Merge adds the orders from other that are not already present.  Regional orders include public
+// structures, so the same order can come back from both endpoints.  Like GetOrders, the merged
+// book expires with the latest of the two.
+func (o *Orders) Merge(other *Orders) {
+	seen := make(map[int64]bool, len(o.Orders))
+	for _, order := range o.Orders {
+		seen[order.ID] = true
+	}
+	for _, order := range other.Orders {
+		if !seen[order.ID] {
+			seen[order.ID] = true
+			o.Orders = append(o.Orders, order)
+		}
+	}
+	if other.ExpiresAt.After(o.ExpiresAt) {
+		o.ExpiresAt = other.ExpiresAt
+	}
+}
+
 type Market struct {
 	RegionID    eveonline.RegionID
+	LocationID  *eveonline.LocationID
 	ExpiresAt   time.Time
 	HighestBuys map[eveonline.TypeID]float64
 	LowestSells map[eveonline.TypeID]float64
+	Aggregates  map[eveonline.TypeID]*MarketAggregate
 }
 
-type esiMarketPrice struct {
+type MarketPrice struct {
 	TypeID        eveonline.TypeID `json:"type_id"`
 	AveragePrice  float64          `json:"average_price"`
 	AdjustedPrice float64          `json:"adjusted_price"`
 }
 
+type MarketPrices map[eveonline.TypeID]*MarketPrice
+
 type AveragePrices map[eveonline.TypeID]float64
 
-func (e *ESI) GetMarket(regionID eveonline.RegionID, locationID *eveonline.LocationID, httpClient *http.Client) (*Market, error) {
-	regionMarketOrdersURL := fmt.Sprintf("https://esi.evetech.net/v1/markets/%d/orders/", regionID)
-	var latestExpiry time.Time
+// GetMarket summarises a region's market.  With a locationID only sell orders at that location
+// and buy orders that can be filled there are counted: systemID is the location's solar system and
+// jumps counts the jumps between two systems for ranged buy orders, see BuyOrderReaches.  Both are
+// ignored for a region-wide summary.
+func (e *ESI) GetMarket(
+	regionID eveonline.RegionID,
+	locationID *eveonline.LocationID,
+	systemID eveonline.SystemID,
+	jumps func(from eveonline.SystemID, to eveonline.SystemID) (int, bool),
+	httpClient *http.Client,
+) (*Market, error) {
+	orders, err := e.GetOrders(regionID, nil, httpClient, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var aggregates map[eveonline.TypeID]*MarketAggregate
+	if locationID == nil {
+		aggregates = orders.Aggregates(nil, nil)
+	} else {
+		aggregates = orders.LocationAggregates(*locationID, systemID, jumps)
+	}
+
 	market := &Market{
 		RegionID:    regionID,
+		LocationID:  locationID,
+		ExpiresAt:   orders.ExpiresAt,
 		HighestBuys: make(map[eveonline.TypeID]float64),
 		LowestSells: make(map[eveonline.TypeID]float64),
+		Aggregates:  aggregates,
 	}
-	err := e.ScanPages(regionMarketOrdersURL, httpClient, func(page *ResponsePage) (bool, error) {
-		if page.ExpiresAt.After(latestExpiry) {
-			latestExpiry = page.ExpiresAt
-		}
-
-		orders := make([]*Order, 0)
-		unmarshalErr := json.Unmarshal(page.Body, &orders)
-		if unmarshalErr != nil {
-			return false, unmarshalErr
+	for typeID, aggregate := range aggregates {
+		if aggregate.Buy.OrderCount > 0 {
+			market.HighestBuys[typeID] = aggregate.Buy.Max
 		}
-
-		for _, order := range orders {
-			if order.IsBuy {
-				previousHighestBuy := 0.0
-				phb, ok := market.HighestBuys[order.TypeID]
-				if ok {
-					previousHighestBuy = phb
-				}
-				market.HighestBuys[order.TypeID] = math.Max(order.Price, previousHighestBuy)
-			} else {
-				previousLowestSell := math.MaxFloat64
-				pls, ok := market.LowestSells[order.TypeID]
-				if ok {
-					previousLowestSell = pls
-				}
-				market.LowestSells[order.TypeID] = math.Min(order.Price, previousLowestSell)
-			}
+		if aggregate.Sell.OrderCount > 0 {
+			market.LowestSells[typeID] = aggregate.Sell.Min
 		}
-
-		return true, nil
-	})
-	if err != nil {
-		return nil, err
 	}
 
-	market.ExpiresAt = latestExpiry
 	return market, nil
 }
 
@@ -110,10 +144,12 @@ func (e *ESI) GetOrders(
 			latestExpiry = rp.ExpiresAt
 		}
 
-		unmarshalErr := json.Unmarshal(rp.Body, &orders)
+		pageOrders := make([]*Order, 0)
+		unmarshalErr := json.Unmarshal(rp.Body, &pageOrders)
 		if unmarshalErr != nil {
 			return nil, unmarshalErr
 		}
+		orders = append(orders, pageOrders...)
 	}
 
 	if locationID != nil {
@@ -129,22 +165,36 @@ func (e *ESI) GetOrders(
 	return &Orders{RegionID: regionID, Orders: orders, ExpiresAt: latestExpiry}, nil
 }
 
-func (e *ESI) GetAverageMarketPrices(httpClient *http.Client) (AveragePrices, error) {
+func (e *ESI) GetMarketPrices(httpClient *http.Client) (MarketPrices, error) {
 	marketPricesURL := "https://esi.evetech.net/v1/markets/prices/"
 	resp, err := e.GetFromESI(marketPricesURL, httpClient, map[string][]string{})
 	if err != nil {
 		return nil, err
 	}
 
-	averagePriceList := make([]*esiMarketPrice, 0)
-	err = json.Unmarshal(resp.Body, &averagePriceList)
+	priceList := make([]*MarketPrice, 0)
+	err = json.Unmarshal(resp.Body, &priceList)
+	if err != nil {
+		return nil, err
+	}
+
+	prices := make(MarketPrices)
+	for _, price := range priceList {
+		prices[price.TypeID] = price
+	}
+
+	return prices, nil
+}
+
+func (e *ESI) GetAverageMarketPrices(httpClient *http.Client) (AveragePrices, error) {
+	prices, err := e.GetMarketPrices(httpClient)
 	if err != nil {
 		return nil, err
 	}
 
 	averagePrices := make(AveragePrices)
-	for _, esiPrice := range averagePriceList {
-		averagePrices[esiPrice.TypeID] = esiPrice.AveragePrice
+	for typeID, price := range prices {
+		averagePrices[typeID] = price.AveragePrice
 	}
 
 	return averagePrices, nil
@@ -0,0 +1,44 @@
+package esi
+
+import (
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetMarket(t *testing.T) {
+	body, err := ioutil.ReadFile("../../test/testdata/rangedMarketOrders.json")
+	if err != nil {
+		t.Fatalf("Failed to read market orders test data: %v", err)
+	}
+	e := &ESI{Cache: noCache{}, HttpClient: &http.Client{Transport: &fileTransport{body: body}}}
+
+	market, err := e.GetMarket(10000002, nil, 0, nil, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 3.95, market.HighestBuys[34])
+	assert.Equal(t, 4.0, market.LowestSells[34])
+
+	// Perimeter is next door, Amarr is out of reach of its 5 jump bid.
+	jumps := func(from eveonline.SystemID, to eveonline.SystemID) (int, bool) {
+		switch from {
+		case 30000144:
+			return 1, true
+		case 30002187:
+			return 40, true
+		}
+		return 0, false
+	}
+	jita := eveonline.LocationID(60003760)
+	market, err = e.GetMarket(10000002, &jita, 30000142, jumps, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 3.9, market.HighestBuys[34])
+	assert.Equal(t, 2, market.Aggregates[34].Buy.OrderCount)
+	assert.Equal(t, 4.0, market.LowestSells[34])
+
+	market, err = e.GetMarket(10000002, &jita, 30000142, nil, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 3.8, market.HighestBuys[34])
+}
@@ -0,0 +1,59 @@
+package esi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+)
+
+type MarketGroup struct {
+	ID            eveonline.MarketGroupID  `json:"market_group_id"`
+	Name          string                   `json:"name"`
+	Description   string                   `json:"description"`
+	ParentGroupID *eveonline.MarketGroupID `json:"parent_group_id,omitempty"`
+	TypeIDs       []eveonline.TypeID       `json:"types"`
+}
+
+const MarketGroupsURL = "https://esi.evetech.net/v1/markets/groups/"
+const MarketGroupURLPattern = "https://esi.evetech.net/v1/markets/groups/%d/"
+
+func (e *ESI) GetMarketGroupIDs(httpClient *http.Client) ([]eveonline.MarketGroupID, error) {
+	resp, err := e.GetFromESI(MarketGroupsURL, httpClient, map[string][]string{})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get market group ids, %v", err)
+	}
+	err = checkStatus(MarketGroupsURL, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	marketGroupIDs := make([]eveonline.MarketGroupID, 0)
+	err = json.Unmarshal(resp.Body, &marketGroupIDs)
+	if err != nil {
+		return nil, fmt.Errorf("Failed while unmarshalling market group ids, %v", err)
+	}
+
+	return marketGroupIDs, nil
+}
+
+func (e *ESI) GetMarketGroup(httpClient *http.Client, marketGroupID eveonline.MarketGroupID) (*MarketGroup, error) {
+	url := fmt.Sprintf(MarketGroupURLPattern, marketGroupID)
+	resp, err := e.GetFromESI(url, httpClient, map[string][]string{})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get market group %d, %v", marketGroupID, err)
+	}
+	err = checkStatus(url, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	marketGroup := new(MarketGroup)
+	err = json.Unmarshal(resp.Body, marketGroup)
+	if err != nil {
+		return nil, fmt.Errorf("Failed while unmarshalling market group %d, %v", marketGroupID, err)
+	}
+
+	return marketGroup, nil
+}
@@ -0,0 +1,176 @@
+package esi
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+)
+
+const (
+	StationOrderRange     = "station"
+	SolarSystemOrderRange = "solarsystem"
+	RegionOrderRange      = "region"
+)
+
+type PriceLevel struct {
+	Price  float64
+	Volume int64
+	Orders int
+}
+
+// OrderFilter picks the orders a helper should consider.  A nil filter accepts every order.
+type OrderFilter func(order *Order) bool
+
+func AtLocation(locationID eveonline.LocationID) OrderFilter {
+	return func(order *Order) bool {
+		return order.LocationID == locationID
+	}
+}
+
+func InSystem(systemID eveonline.SystemID) OrderFilter {
+	return func(order *Order) bool {
+		return order.SystemID == systemID
+	}
+}
+
+// BuyOrderReaches accepts buy orders whose range covers a seller at locationID in systemID.
+// jumps returns the number of jumps between two systems, or false when there is no route; it is
+// only called for orders with a numeric jump range placed in another system.  A nil jumps treats
+// those orders as out of range.
+func BuyOrderReaches(
+	locationID eveonline.LocationID,
+	systemID eveonline.SystemID,
+	jumps func(from eveonline.SystemID, to eveonline.SystemID) (int, bool),
+) OrderFilter {
+	reachesSystem := BuyOrderReachesSystem(systemID, jumps)
+	return func(order *Order) bool {
+		if order.IsBuy && order.Range == StationOrderRange {
+			return order.LocationID == locationID
+		}
+		return reachesSystem(order)
+	}
+}
+
+// BuyOrderReachesSystem accepts buy orders that a seller at some station in systemID can fill.
+func BuyOrderReachesSystem(
+	systemID eveonline.SystemID,
+	jumps func(from eveonline.SystemID, to eveonline.SystemID) (int, bool),
+) OrderFilter {
+	return func(order *Order) bool {
+		if !order.IsBuy {
+			return false
+		}
+		switch order.Range {
+		case StationOrderRange, SolarSystemOrderRange:
+			return order.SystemID == systemID
+		case RegionOrderRange:
+			return true
+		}
+		orderRange, err := strconv.Atoi(order.Range)
+		if err != nil {
+			return false
+		}
+		if order.SystemID == systemID {
+			return true
+		}
+		if jumps == nil {
+			return false
+		}
+		distance, ok := jumps(order.SystemID, systemID)
+		return ok && distance <= orderRange
+	}
+}
+
+// side returns the buy or sell orders for a type, best price first.
+func (o *Orders) side(typeID eveonline.TypeID, isBuy bool, filter OrderFilter) []*Order {
+	orders := make([]*Order, 0)
+	for _, order := range o.Orders {
+		if order.TypeID != typeID || order.IsBuy != isBuy || order.VolumeRemain <= 0 {
+			continue
+		}
+		if filter != nil && !filter(order) {
+			continue
+		}
+		orders = append(orders, order)
+	}
+	sort.SliceStable(orders, func(i, j int) bool {
+		if isBuy {
+			return orders[i].Price > orders[j].Price
+		}
+		return orders[i].Price < orders[j].Price
+	})
+	return orders
+}
+
+// Depth groups the buy or sell side of a type's book into price levels, best price first.
+func (o *Orders) Depth(typeID eveonline.TypeID, isBuy bool, filter OrderFilter) []PriceLevel {
+	levels := make([]PriceLevel, 0)
+	for _, order := range o.side(typeID, isBuy, filter) {
+		if len(levels) > 0 && levels[len(levels)-1].Price == order.Price {
+			levels[len(levels)-1].Volume += order.VolumeRemain
+			levels[len(levels)-1].Orders++
+			continue
+		}
+		levels = append(levels, PriceLevel{Price: order.Price, Volume: order.VolumeRemain, Orders: 1})
+	}
+	return levels
+}
+
+// VolumeWeightedPrice walks the book from the best price until quantity units are filled and
+// returns the average price paid along with the units actually filled.  Use isBuy false to price
+// buying from sell orders and true to price selling into buy orders; buy orders whose minimum
+// volume is larger than what is left to fill are skipped.
+func (o *Orders) VolumeWeightedPrice(typeID eveonline.TypeID, isBuy bool, quantity int64, filter OrderFilter) (float64, int64) {
+	var filled int64
+	var total float64
+	for _, order := range o.side(typeID, isBuy, filter) {
+		remaining := quantity - filled
+		if remaining <= 0 {
+			break
+		}
+		if order.MinVolume > remaining {
+			continue
+		}
+		units := order.VolumeRemain
+		if units > remaining {
+			units = remaining
+		}
+		filled += units
+		total += float64(units) * order.Price
+	}
+	if filled == 0 {
+		return 0.0, 0
+	}
+	return total / float64(filled), filled
+}
+
+func (o *Orders) BestBid(typeID eveonline.TypeID, filter OrderFilter) (*Order, bool) {
+	orders := o.side(typeID, true, filter)
+	if len(orders) == 0 {
+		return nil, false
+	}
+	return orders[0], true
+}
+
+func (o *Orders) BestAsk(typeID eveonline.TypeID, filter OrderFilter) (*Order, bool) {
+	orders := o.side(typeID, false, filter)
+	if len(orders) == 0 {
+		return nil, false
+	}
+	return orders[0], true
+}
+
+// Spread is the lowest sell price minus the highest buy price.  ok is false when either side of
+// the book is empty.
+func (o *Orders) Spread(typeID eveonline.TypeID, filter OrderFilter) (float64, bool) {
+	bid, ok := o.BestBid(typeID, filter)
+	if !ok {
+		return 0.0, false
+	}
+	ask, ok := o.BestAsk(typeID, filter)
+	if !ok {
+		return 0.0, false
+	}
+	return ask.Price - bid.Price, true
+}
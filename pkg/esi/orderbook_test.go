@@ -0,0 +1,124 @@
+package esi
+
+import (
+	"testing"
+
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+	"github.com/stretchr/testify/assert"
+)
+
+func testOrders() *Orders {
+	return &Orders{RegionID: 10000002, Orders: []*Order{
+		{ID: 1, TypeID: 34, Price: 5.0, VolumeRemain: 100, LocationID: 60003760, SystemID: 30000142, Range: RegionOrderRange},
+		{ID: 2, TypeID: 34, Price: 4.5, VolumeRemain: 50, LocationID: 60003760, SystemID: 30000142, Range: RegionOrderRange},
+		{ID: 3, TypeID: 34, Price: 5.0, VolumeRemain: 25, LocationID: 60003761, SystemID: 30000144, Range: RegionOrderRange},
+		{ID: 4, TypeID: 34, Price: 4.0, VolumeRemain: 200, IsBuy: true, LocationID: 60003760, SystemID: 30000142, Range: StationOrderRange},
+		{ID: 5, TypeID: 34, Price: 4.2, VolumeRemain: 10, MinVolume: 10, IsBuy: true, LocationID: 60003761, SystemID: 30000144, Range: "1"},
+		{ID: 6, TypeID: 34, Price: 4.1, VolumeRemain: 30, IsBuy: true, LocationID: 60003762, SystemID: 30000145, Range: SolarSystemOrderRange},
+		{ID: 7, TypeID: 35, Price: 9.0, VolumeRemain: 10, LocationID: 60003760, SystemID: 30000142, Range: RegionOrderRange},
+	}}
+}
+
+func TestDepthAndSpread(t *testing.T) {
+	orders := testOrders()
+
+	assert.Equal(t, []PriceLevel{
+		{Price: 4.5, Volume: 50, Orders: 1},
+		{Price: 5.0, Volume: 125, Orders: 2},
+	}, orders.Depth(34, false, nil))
+	assert.Equal(t, []PriceLevel{{Price: 5.0, Volume: 25, Orders: 1}}, orders.Depth(34, false, InSystem(30000144)))
+
+	spread, ok := orders.Spread(34, nil)
+	assert.True(t, ok)
+	assert.InDelta(t, 0.3, spread, 0.0001)
+	_, ok = orders.Spread(35, nil)
+	assert.False(t, ok)
+}
+
+func TestVolumeWeightedPrice(t *testing.T) {
+	orders := testOrders()
+
+	price, filled := orders.VolumeWeightedPrice(34, false, 100, nil)
+	assert.Equal(t, int64(100), filled)
+	assert.InDelta(t, 4.75, price, 0.0001)
+
+	price, filled = orders.VolumeWeightedPrice(34, false, 1000, AtLocation(60003760))
+	assert.Equal(t, int64(150), filled)
+	assert.InDelta(t, (50*4.5+100*5.0)/150, price, 0.0001)
+
+	// The 4.2 buy order needs at least 10 units, so a 5 unit sale skips it.
+	price, filled = orders.VolumeWeightedPrice(34, true, 5, nil)
+	assert.Equal(t, int64(5), filled)
+	assert.InDelta(t, 4.1, price, 0.0001)
+}
+
+func TestBuyOrderReaches(t *testing.T) {
+	orders := testOrders()
+	jumps := func(from eveonline.SystemID, to eveonline.SystemID) (int, bool) {
+		if from == to {
+			return 0, true
+		}
+		return 1, true
+	}
+
+	bid, ok := orders.BestBid(34, BuyOrderReaches(60003760, 30000142, jumps))
+	assert.True(t, ok)
+	assert.Equal(t, int64(5), bid.ID)
+
+	bid, ok = orders.BestBid(34, BuyOrderReaches(60003999, 30000145, func(from eveonline.SystemID, to eveonline.SystemID) (int, bool) {
+		return 0, false
+	}))
+	assert.True(t, ok)
+	assert.Equal(t, int64(6), bid.ID)
+
+	_, ok = orders.BestBid(34, BuyOrderReaches(60003999, 30000143, func(from eveonline.SystemID, to eveonline.SystemID) (int, bool) {
+		return 2, true
+	}))
+	assert.False(t, ok)
+
+	ask, ok := orders.BestAsk(34, AtLocation(60003761))
+	assert.True(t, ok)
+	assert.Equal(t, int64(3), ask.ID)
+}
+
+func TestPriceTick(t *testing.T) {
+	assert.Equal(t, 0.01, PriceTick(5.25))
+	assert.InDelta(t, 1.0, PriceTick(1234.56), 0.0000001)
+	assert.InDelta(t, 10000.0, PriceTick(12345678.0), 0.0000001)
+}
+
+func TestUndercuts(t *testing.T) {
+	orders := testOrders()
+	orders.Orders = append(orders.Orders, &Order{ID: 10, TypeID: 34, Price: 3.5, VolumeRemain: 10, IsBuy: true, LocationID: 60003760, SystemID: 30000142, Range: RegionOrderRange})
+	own := []*OwnOrder{
+		{Order: Order{ID: 1, TypeID: 34, Price: 5.0, VolumeRemain: 100, LocationID: 60003760}, RegionID: 10000002},
+		{Order: Order{ID: 4, TypeID: 34, Price: 4.0, VolumeRemain: 200, IsBuy: true, LocationID: 60003760, Range: StationOrderRange}, RegionID: 10000002},
+		{Order: Order{ID: 7, TypeID: 35, Price: 9.0, VolumeRemain: 10, LocationID: 60003760}, RegionID: 10000002},
+		{Order: Order{ID: 8, TypeID: 34, Price: 5.2, VolumeRemain: 10, LocationID: 60003760}, RegionID: 10000002, State: OrderStateExpired},
+		// A region wide bid in Domain is not outbid by bids in The Forge.
+		{Order: Order{ID: 9, TypeID: 34, Price: 3.0, VolumeRemain: 100, IsBuy: true, LocationID: 60008494, Range: RegionOrderRange}, RegionID: 10000043},
+	}
+
+	systems := map[eveonline.LocationID]eveonline.SystemID{60003760: 30000142, 60003761: 30000144}
+	undercuts := orders.Undercuts(own, systems, nil)
+	assert.Len(t, undercuts, 1)
+	assert.Equal(t, int64(1), undercuts[0].Order.ID)
+	assert.Equal(t, int64(2), undercuts[0].Competitor.ID)
+	assert.Equal(t, 50, undercuts[0].Ticks)
+	assert.Equal(t, 1, undercuts[0].Competitors)
+
+	// The 4.2 order in Perimeter has a one jump range, so it reaches our buy order in Jita.
+	oneJump := func(from eveonline.SystemID, to eveonline.SystemID) (int, bool) {
+		return 1, true
+	}
+	undercuts = orders.Undercuts(own, systems, oneJump)
+	assert.Len(t, undercuts, 2)
+	assert.Equal(t, int64(5), undercuts[1].Competitor.ID)
+	assert.Equal(t, 20, undercuts[1].Ticks)
+
+	// Moved to Perimeter, it competes without a route planner.
+	own[1].LocationID = 60003761
+	undercuts = orders.Undercuts(own, systems, nil)
+	assert.Len(t, undercuts, 2)
+	assert.Equal(t, int64(5), undercuts[1].Competitor.ID)
+}
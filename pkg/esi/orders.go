@@ -0,0 +1,165 @@
+package esi
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+)
+
+const (
+	OrderStateCancelled = "cancelled"
+	OrderStateExpired   = "expired"
+)
+
+// OwnOrder is an order placed by one of our characters or corporations.  Corporation orders carry
+// IssuedBy and WalletDivision, character orders IsCorporation; State is only set in order history.
+type OwnOrder struct {
+	Order
+	RegionID       eveonline.RegionID    `json:"region_id"`
+	Escrow         float64               `json:"escrow"`
+	IsCorporation  bool                  `json:"is_corporation"`
+	IssuedBy       eveonline.CharacterID `json:"issued_by"`
+	WalletDivision int                   `json:"wallet_division"`
+	State          string                `json:"state"`
+}
+
+const CharacterOrdersURLPattern = "https://esi.evetech.net/v2/characters/%d/orders/"
+const CharacterOrderHistoryURLPattern = "https://esi.evetech.net/v1/characters/%d/orders/history/"
+const CorporationOrdersURLPattern = "https://esi.evetech.net/v3/corporations/%d/orders/"
+const CorporationOrderHistoryURLPattern = "https://esi.evetech.net/v2/corporations/%d/orders/history/"
+
+func (e *ESI) GetCharacterOrders(authdClient *http.Client, characterID eveonline.CharacterID) ([]*OwnOrder, error) {
+	url := fmt.Sprintf(CharacterOrdersURLPattern, characterID)
+	resp, err := e.GetFromESI(url, authdClient, map[string][]string{})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get orders for character id %d, %v", characterID, err)
+	}
+
+	orders := make([]*OwnOrder, 0)
+	err = json.Unmarshal(resp.Body, &orders)
+	if err != nil {
+		return nil, fmt.Errorf("Failed while unmarshalling orders for character %d, %v", characterID, err)
+	}
+
+	return orders, nil
+}
+
+func (e *ESI) GetCharacterOrderHistory(authdClient *http.Client, characterID eveonline.CharacterID) ([]*OwnOrder, error) {
+	orders, err := e.getOwnOrderPages(authdClient, fmt.Sprintf(CharacterOrderHistoryURLPattern, characterID))
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get order history for character id %d, %v", characterID, err)
+	}
+	return orders, nil
+}
+
+func (e *ESI) GetCorporationOrders(authdClient *http.Client, corporationID eveonline.CorporationID) ([]*OwnOrder, error) {
+	orders, err := e.getOwnOrderPages(authdClient, fmt.Sprintf(CorporationOrdersURLPattern, corporationID))
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get orders for corporation id %d, %v", corporationID, err)
+	}
+	return orders, nil
+}
+
+func (e *ESI) GetCorporationOrderHistory(authdClient *http.Client, corporationID eveonline.CorporationID) ([]*OwnOrder, error) {
+	orders, err := e.getOwnOrderPages(authdClient, fmt.Sprintf(CorporationOrderHistoryURLPattern, corporationID))
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get order history for corporation id %d, %v", corporationID, err)
+	}
+	return orders, nil
+}
+
+func (e *ESI) getOwnOrderPages(authdClient *http.Client, url string) ([]*OwnOrder, error) {
+	allPages, err := e.GetAllPages(url, 1, map[string][]string{}, authdClient)
+	if err != nil {
+		return nil, err
+	}
+
+	orders := make([]*OwnOrder, 0)
+	for _, page := range allPages {
+		pageOrders := make([]*OwnOrder, 0)
+		err = json.Unmarshal(page.Body, &pageOrders)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, pageOrders...)
+	}
+
+	return orders, nil
+}
+
+// PriceTick is the smallest price change the market accepts at this price: prices are limited to
+// four significant digits, and never finer than 0.01 ISK.
+func PriceTick(price float64) float64 {
+	if price <= 0 {
+		return 0.01
+	}
+	return math.Max(0.01, math.Pow(10, math.Floor(math.Log10(price))-3))
+}
+
+// Undercut reports that a better priced order competes with one of ours.  ESI does not say who
+// owns market orders, so the competitor is identified by its order only.
+type Undercut struct {
+	Order       *OwnOrder
+	Competitor  *Order
+	Ticks       int
+	Competitors int
+}
+
+// Undercuts compares our open orders with the order book; orders in other regions are skipped.
+// Sell orders compete with cheaper sell orders at the same location.  Buy orders compete with
+// higher buy orders that a seller at our location could fill instead: systems gives the solar
+// system of each location we have buy orders at, since ESI leaves it out of our own orders, and
+// jumps is passed on to BuyOrderReaches.
+func (o *Orders) Undercuts(
+	own []*OwnOrder,
+	systems map[eveonline.LocationID]eveonline.SystemID,
+	jumps func(from eveonline.SystemID, to eveonline.SystemID) (int, bool),
+) []*Undercut {
+	ownIDs := make(map[int64]bool, len(own))
+	for _, order := range own {
+		ownIDs[order.ID] = true
+	}
+
+	undercuts := make([]*Undercut, 0)
+	for _, order := range own {
+		if order.VolumeRemain <= 0 || order.State != "" || order.RegionID != o.RegionID {
+			continue
+		}
+
+		var competes OrderFilter
+		if order.IsBuy {
+			systemID := order.SystemID
+			if systemID == 0 {
+				systemID = systems[order.LocationID]
+			}
+			reaches := BuyOrderReaches(order.LocationID, systemID, jumps)
+			competes = func(other *Order) bool {
+				return other.Price > order.Price && reaches(other)
+			}
+		} else {
+			competes = func(other *Order) bool {
+				return other.Price < order.Price && other.LocationID == order.LocationID
+			}
+		}
+		competitors := o.side(order.TypeID, order.IsBuy, func(other *Order) bool {
+			return !ownIDs[other.ID] && competes(other)
+		})
+		if len(competitors) == 0 {
+			continue
+		}
+
+		best := competitors[0]
+		ticks := int(math.Ceil(math.Abs(best.Price-order.Price)/PriceTick(order.Price) - 1e-9))
+		undercuts = append(undercuts, &Undercut{
+			Order:       order,
+			Competitor:  best,
+			Ticks:       ticks,
+			Competitors: len(competitors),
+		})
+	}
+
+	return undercuts
+}
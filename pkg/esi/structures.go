@@ -0,0 +1,107 @@
+package esi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+)
+
+type Structure struct {
+	ID       eveonline.StructureID   `json:"-"`
+	Name     string                  `json:"name"`
+	OwnerID  eveonline.CorporationID `json:"owner_id"`
+	SystemID eveonline.SystemID      `json:"solar_system_id"`
+	TypeID   eveonline.TypeID        `json:"type_id"`
+	Position Position                `json:"position"`
+}
+
+// StructureAccessDeniedError is returned when ESI answers 403, which for structures means the
+// character has no docking access.
+type StructureAccessDeniedError struct {
+	StructureID eveonline.StructureID
+}
+
+func (e StructureAccessDeniedError) Error() string {
+	return fmt.Sprintf("No docking access to structure %d", e.StructureID)
+}
+
+const StructureURLPattern = "https://esi.evetech.net/v2/universe/structures/%d/"
+const PublicStructuresURL = "https://esi.evetech.net/v1/universe/structures/"
+const StructureMarketOrdersURLPattern = "https://esi.evetech.net/v1/markets/structures/%d/"
+
+func (e *ESI) GetStructure(authdClient *http.Client, structureID eveonline.StructureID) (*Structure, error) {
+	url := fmt.Sprintf(StructureURLPattern, structureID)
+	resp, err := e.GetFromESI(url, authdClient, map[string][]string{})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get structure %d, %v", structureID, err)
+	}
+	if resp.ResponseStatusCode == http.StatusForbidden {
+		return nil, StructureAccessDeniedError{StructureID: structureID}
+	}
+	err = checkStatus(url, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	structure := new(Structure)
+	err = json.Unmarshal(resp.Body, structure)
+	if err != nil {
+		return nil, fmt.Errorf("Failed while unmarshalling structure %d, %v", structureID, err)
+	}
+	structure.ID = structureID
+
+	return structure, nil
+}
+
+// GetPublicMarketStructureIDs lists structures with a market that anyone can see.  Seeing the
+// market does not guarantee docking access, so GetStructureOrders can still be refused.
+func (e *ESI) GetPublicMarketStructureIDs(httpClient *http.Client) ([]eveonline.StructureID, error) {
+	resp, err := e.GetFromESI(PublicStructuresURL, httpClient, map[string][]string{"filter": []string{"market"}})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get public market structures, %v", err)
+	}
+
+	structureIDs := make([]eveonline.StructureID, 0)
+	err = json.Unmarshal(resp.Body, &structureIDs)
+	if err != nil {
+		return nil, fmt.Errorf("Failed while unmarshalling public market structures, %v", err)
+	}
+
+	return structureIDs, nil
+}
+
+// GetStructureOrders returns every order on a structure market.  ESI does not include the system
+// on structure orders, so SystemID is left unset; RegionID is unset as well.
+func (e *ESI) GetStructureOrders(authdClient *http.Client, structureID eveonline.StructureID) (*Orders, error) {
+	url := fmt.Sprintf(StructureMarketOrdersURLPattern, structureID)
+	allPages, err := e.GetAllPages(url, 1, map[string][]string{}, authdClient)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get orders for structure %d, %v", structureID, err)
+	}
+
+	var latestExpiry time.Time
+	orders := make([]*Order, 0)
+	for _, page := range allPages {
+		if page.ResponseStatusCode == http.StatusForbidden {
+			return nil, StructureAccessDeniedError{StructureID: structureID}
+		}
+		if page.ResponseStatusCode != http.StatusOK {
+			return nil, fmt.Errorf("Failed to get orders for structure %d, status %d", structureID, page.ResponseStatusCode)
+		}
+		if page.ExpiresAt.After(latestExpiry) {
+			latestExpiry = page.ExpiresAt
+		}
+
+		pageOrders := make([]*Order, 0)
+		err = json.Unmarshal(page.Body, &pageOrders)
+		if err != nil {
+			return nil, fmt.Errorf("Failed while unmarshalling orders for structure %d, %v", structureID, err)
+		}
+		orders = append(orders, pageOrders...)
+	}
+
+	return &Orders{Orders: orders, ExpiresAt: latestExpiry}, nil
+}
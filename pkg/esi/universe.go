@@ -3,16 +3,19 @@ package esi
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
 
 	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
 )
 
 type Type struct {
-	ID        eveonline.TypeID  `json:"type_id"`
-	GroupID   eveonline.GroupID `json:"group_id"`
-	Volume    float64           `json:"volume"`
-	Name      string            `json:"name"`
-	Published bool              `json:"published"`
+	ID              eveonline.TypeID     `json:"type_id"`
+	GroupID         eveonline.GroupID    `json:"group_id"`
+	Volume          float64              `json:"volume"`
+	Name            string               `json:"name"`
+	Published       bool                 `json:"published"`
+	DogmaAttributes []TypeDogmaAttribute `json:"dogma_attributes,omitempty"`
+	DogmaEffects    []TypeDogmaEffect    `json:"dogma_effects,omitempty"`
 }
 
 type Group struct {
@@ -38,14 +41,65 @@ type Station struct {
 	RegionID        eveonline.RegionID        `json:",omitempty"`
 }
 
+type Position struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+	Z float64 `json:"z"`
+}
+
 type System struct {
 	ID              eveonline.SystemID        `json:"system_id"`
 	ConstellationID eveonline.ConstellationID `json:"constellation_id"`
+	Name            string                    `json:"name"`
+	SecurityStatus  float64                   `json:"security_status"`
+	SecurityClass   string                    `json:"security_class"`
+	Position        Position                  `json:"position"`
+	StargateIDs     []eveonline.StargateID    `json:"stargates"`
+	StationIDs      []eveonline.StationID     `json:"stations"`
 }
 
 type Constellation struct {
-	ID       eveonline.ConstellationID `json:"constellation_id"`
-	RegionID eveonline.RegionID        `json:"region_id"`
+	ID        eveonline.ConstellationID `json:"constellation_id"`
+	RegionID  eveonline.RegionID        `json:"region_id"`
+	Name      string                    `json:"name"`
+	SystemIDs []eveonline.SystemID      `json:"systems"`
+}
+
+type StargateDestination struct {
+	StargateID eveonline.StargateID `json:"stargate_id"`
+	SystemID   eveonline.SystemID   `json:"system_id"`
+}
+
+type Stargate struct {
+	ID          eveonline.StargateID `json:"stargate_id"`
+	Name        string               `json:"name"`
+	SystemID    eveonline.SystemID   `json:"system_id"`
+	Destination StargateDestination  `json:"destination"`
+}
+
+type UniverseName struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// UniverseIDs holds the exact name matches from /universe/ids/, grouped by kind.
+type UniverseIDs struct {
+	Agents         []UniverseName `json:"agents"`
+	Alliances      []UniverseName `json:"alliances"`
+	Characters     []UniverseName `json:"characters"`
+	Constellations []UniverseName `json:"constellations"`
+	Corporations   []UniverseName `json:"corporations"`
+	Factions       []UniverseName `json:"factions"`
+	InventoryTypes []UniverseName `json:"inventory_types"`
+	Regions        []UniverseName `json:"regions"`
+	Stations       []UniverseName `json:"stations"`
+	Systems        []UniverseName `json:"systems"`
+}
+
+type Region struct {
+	ID               eveonline.RegionID          `json:"region_id"`
+	Name             string                      `json:"name"`
+	ConstellationIDs []eveonline.ConstellationID `json:"constellations"`
 }
 
 const TypeURLPattern = "https://esi.evetech.net/v3/universe/types/%d/"
@@ -54,13 +108,20 @@ const CategoryURLPattern = "https://esi.evetech.net/v1/universe/categories/%d/"
 const StationURLPattern = "https://esi.evetech.net/v2/universe/stations/%d/"
 const SystemURLPattern = "https://esi.evetech.net/v4/universe/systems/%d/"
 const ConstellationURLPattern = "https://esi.evetech.net/v1/universe/constellations/%d/"
+const RegionURLPattern = "https://esi.evetech.net/v1/universe/regions/%d/"
+const StargateURLPattern = "https://esi.evetech.net/v1/universe/stargates/%d/"
+const UniverseIDsURL = "https://esi.evetech.net/v1/universe/ids/"
+
+// UniverseIDsMaxNames is the most names ESI accepts in one /universe/ids/ request.
+const UniverseIDsMaxNames = 500
 
 func (e *ESI) GetType(typeID eveonline.TypeID) (*Type, error) {
-	resp, err := e.GetFromESI(
-		fmt.Sprintf(TypeURLPattern, typeID),
-		nil,
-		map[string][]string{},
-	)
+	url := fmt.Sprintf(TypeURLPattern, typeID)
+	resp, err := e.GetFromESI(url, nil, map[string][]string{})
+	if err != nil {
+		return nil, err
+	}
+	err = checkStatus(url, resp)
 	if err != nil {
 		return nil, err
 	}
@@ -75,11 +136,12 @@ func (e *ESI) GetType(typeID eveonline.TypeID) (*Type, error) {
 }
 
 func (e *ESI) GetGroup(groupID eveonline.GroupID) (*Group, error) {
-	resp, err := e.GetFromESI(
-		fmt.Sprintf(GroupURLPattern, groupID),
-		nil,
-		map[string][]string{},
-	)
+	url := fmt.Sprintf(GroupURLPattern, groupID)
+	resp, err := e.GetFromESI(url, nil, map[string][]string{})
+	if err != nil {
+		return nil, err
+	}
+	err = checkStatus(url, resp)
 	if err != nil {
 		return nil, err
 	}
@@ -94,11 +156,12 @@ func (e *ESI) GetGroup(groupID eveonline.GroupID) (*Group, error) {
 }
 
 func (e *ESI) GetCategory(categoryID eveonline.CategoryID) (*Category, error) {
-	resp, err := e.GetFromESI(
-		fmt.Sprintf(CategoryURLPattern, categoryID),
-		nil,
-		map[string][]string{},
-	)
+	url := fmt.Sprintf(CategoryURLPattern, categoryID)
+	resp, err := e.GetFromESI(url, nil, map[string][]string{})
+	if err != nil {
+		return nil, err
+	}
+	err = checkStatus(url, resp)
 	if err != nil {
 		return nil, err
 	}
@@ -113,11 +176,12 @@ func (e *ESI) GetCategory(categoryID eveonline.CategoryID) (*Category, error) {
 }
 
 func (e *ESI) GetStation(stationID eveonline.StationID) (*Station, error) {
-	resp, err := e.GetFromESI(
-		fmt.Sprintf(StationURLPattern, stationID),
-		nil,
-		map[string][]string{},
-	)
+	url := fmt.Sprintf(StationURLPattern, stationID)
+	resp, err := e.GetFromESI(url, nil, map[string][]string{})
+	if err != nil {
+		return nil, err
+	}
+	err = checkStatus(url, resp)
 	if err != nil {
 		return nil, err
 	}
@@ -145,11 +209,12 @@ func (e *ESI) GetStation(stationID eveonline.StationID) (*Station, error) {
 }
 
 func (e *ESI) GetSystem(systemID eveonline.SystemID) (*System, error) {
-	resp, err := e.GetFromESI(
-		fmt.Sprintf(SystemURLPattern, systemID),
-		nil,
-		map[string][]string{},
-	)
+	url := fmt.Sprintf(SystemURLPattern, systemID)
+	resp, err := e.GetFromESI(url, nil, map[string][]string{})
+	if err != nil {
+		return nil, err
+	}
+	err = checkStatus(url, resp)
 	if err != nil {
 		return nil, err
 	}
@@ -164,11 +229,12 @@ func (e *ESI) GetSystem(systemID eveonline.SystemID) (*System, error) {
 }
 
 func (e *ESI) GetConstellation(constellationID eveonline.ConstellationID) (*Constellation, error) {
-	resp, err := e.GetFromESI(
-		fmt.Sprintf(ConstellationURLPattern, constellationID),
-		nil,
-		map[string][]string{},
-	)
+	url := fmt.Sprintf(ConstellationURLPattern, constellationID)
+	resp, err := e.GetFromESI(url, nil, map[string][]string{})
+	if err != nil {
+		return nil, err
+	}
+	err = checkStatus(url, resp)
 	if err != nil {
 		return nil, err
 	}
@@ -181,3 +247,78 @@ func (e *ESI) GetConstellation(constellationID eveonline.ConstellationID) (*Cons
 
 	return constellationObj, nil
 }
+
+func (e *ESI) GetRegion(regionID eveonline.RegionID) (*Region, error) {
+	url := fmt.Sprintf(RegionURLPattern, regionID)
+	resp, err := e.GetFromESI(url, nil, map[string][]string{})
+	if err != nil {
+		return nil, err
+	}
+	err = checkStatus(url, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	regionObj := new(Region)
+	err = json.Unmarshal(resp.Body, &regionObj)
+	if err != nil {
+		return nil, err
+	}
+
+	return regionObj, nil
+}
+
+func (e *ESI) GetStargate(stargateID eveonline.StargateID) (*Stargate, error) {
+	url := fmt.Sprintf(StargateURLPattern, stargateID)
+	resp, err := e.GetFromESI(url, nil, map[string][]string{})
+	if err != nil {
+		return nil, err
+	}
+	err = checkStatus(url, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	stargateObj := new(Stargate)
+	err = json.Unmarshal(resp.Body, &stargateObj)
+	if err != nil {
+		return nil, err
+	}
+
+	return stargateObj, nil
+}
+
+// GetUniverseIDs resolves names to ids, splitting them into as many requests as ESI requires.
+// The endpoint is a POST, so the responses are not cached.
+func (e *ESI) GetUniverseIDs(httpClient *http.Client, names []string) (*UniverseIDs, error) {
+	ids := new(UniverseIDs)
+	for start := 0; start < len(names); start += UniverseIDsMaxNames {
+		end := start + UniverseIDsMaxNames
+		if end > len(names) {
+			end = len(names)
+		}
+
+		resp, err := e.SendToESI(http.MethodPost, UniverseIDsURL, httpClient, names[start:end])
+		if err != nil {
+			return nil, fmt.Errorf("Failed to resolve names, %v", err)
+		}
+
+		page := new(UniverseIDs)
+		err = json.Unmarshal(resp.Body, page)
+		if err != nil {
+			return nil, fmt.Errorf("Failed while unmarshalling resolved names, %v", err)
+		}
+		ids.Agents = append(ids.Agents, page.Agents...)
+		ids.Alliances = append(ids.Alliances, page.Alliances...)
+		ids.Characters = append(ids.Characters, page.Characters...)
+		ids.Constellations = append(ids.Constellations, page.Constellations...)
+		ids.Corporations = append(ids.Corporations, page.Corporations...)
+		ids.Factions = append(ids.Factions, page.Factions...)
+		ids.InventoryTypes = append(ids.InventoryTypes, page.InventoryTypes...)
+		ids.Regions = append(ids.Regions, page.Regions...)
+		ids.Stations = append(ids.Stations, page.Stations...)
+		ids.Systems = append(ids.Systems, page.Systems...)
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,33 @@
+package esi
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetUniverseIDs(t *testing.T) {
+	transport := &fileTransport{body: []byte(`{"inventory_types":[{"id":34,"name":"Tritanium"}],"systems":[{"id":30000142,"name":"Jita"}]}`)}
+	e := &ESI{Cache: noCache{}, HttpClient: &http.Client{Transport: transport}}
+
+	names := make([]string, UniverseIDsMaxNames+1)
+	for i := range names {
+		names[i] = "Tritanium"
+	}
+	ids, err := e.GetUniverseIDs(nil, names)
+	assert.Nil(t, err)
+	assert.Len(t, transport.requests, 2)
+	assert.Equal(t, http.MethodPost, transport.requests[0].Method)
+	assert.Equal(t, "application/json", transport.requests[0].Header.Get("Content-Type"))
+	assert.Equal(t, []UniverseName{{ID: 34, Name: "Tritanium"}, {ID: 34, Name: "Tritanium"}}, ids.InventoryTypes)
+	assert.Len(t, ids.Systems, 2)
+
+	body, err := ioutil.ReadAll(transport.requests[1].Body)
+	assert.Nil(t, err)
+	sent := make([]string, 0)
+	assert.Nil(t, json.Unmarshal(body, &sent))
+	assert.Equal(t, []string{"Tritanium"}, sent)
+}
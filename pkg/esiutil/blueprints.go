@@ -0,0 +1,49 @@
+package esiutil
+
+import (
+	"sort"
+
+	"github.com/pequalsnp/go-eveonline/pkg/esi"
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+	"github.com/pequalsnp/go-eveonline/pkg/sde"
+)
+
+type OwnedBlueprint struct {
+	Blueprint    *esi.Blueprint
+	SDEBlueprint *sde.Blueprint
+}
+
+// OwnedBlueprintsForProduct returns the owned blueprints able to produce productTypeID, best
+// material efficiency first.
+func OwnedBlueprintsForProduct(
+	owned []*esi.Blueprint,
+	blueprints sde.ProductBlueprintMap,
+	productTypeID eveonline.TypeID,
+	originalsOnly bool,
+) []*OwnedBlueprint {
+	producing := make(map[eveonline.TypeID]*sde.Blueprint)
+	for _, blueprint := range blueprints[productTypeID] {
+		producing[blueprint.BlueprintTypeID] = blueprint
+	}
+
+	matches := make([]*OwnedBlueprint, 0)
+	for _, ownedBlueprint := range owned {
+		if originalsOnly && !ownedBlueprint.IsOriginal() {
+			continue
+		}
+		blueprint, ok := producing[ownedBlueprint.TypeID]
+		if !ok {
+			continue
+		}
+		matches = append(matches, &OwnedBlueprint{Blueprint: ownedBlueprint, SDEBlueprint: blueprint})
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Blueprint.MaterialEfficiency != matches[j].Blueprint.MaterialEfficiency {
+			return matches[i].Blueprint.MaterialEfficiency > matches[j].Blueprint.MaterialEfficiency
+		}
+		return matches[i].Blueprint.TimeEfficiency > matches[j].Blueprint.TimeEfficiency
+	})
+
+	return matches
+}
@@ -0,0 +1,42 @@
+package esiutil
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/pequalsnp/go-eveonline/pkg/esi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOwnedBlueprintsForProduct(t *testing.T) {
+	contents, err := ioutil.ReadFile("../../test/testdata/characterBlueprints.json")
+	if err != nil {
+		t.Fatalf("Failed to read character blueprints test data: %v", err)
+	}
+	owned := make([]*esi.Blueprint, 0)
+	err = json.Unmarshal(contents, &owned)
+	if err != nil {
+		t.Fatalf("Failed to unmarshal character blueprints test data: %v", err)
+	}
+	blueprints := loadTestBlueprints(t)
+
+	itemIDs := func(matches []*OwnedBlueprint) []int64 {
+		ids := make([]int64, 0, len(matches))
+		for _, match := range matches {
+			ids = append(ids, match.Blueprint.ItemID)
+		}
+		return ids
+	}
+
+	rifters := OwnedBlueprintsForProduct(owned, blueprints, 587, false)
+	assert.Equal(t, []int64{1015116533321, 1015116533323, 1015116533320}, itemIDs(rifters))
+	assert.Equal(t, blueprints[587][0], rifters[0].SDEBlueprint)
+
+	originals := OwnedBlueprintsForProduct(owned, blueprints, 587, true)
+	assert.Equal(t, []int64{1015116533323, 1015116533320}, itemIDs(originals))
+
+	// The stacked originals count as originals.
+	assert.Len(t, OwnedBlueprintsForProduct(owned, blueprints, 165, true), 1)
+	assert.Empty(t, OwnedBlueprintsForProduct(owned, blueprints, 34, false))
+}
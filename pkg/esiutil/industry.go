@@ -0,0 +1,83 @@
+package esiutil
+
+import (
+	"time"
+
+	"github.com/pequalsnp/go-eveonline/pkg/esi"
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+	"github.com/pequalsnp/go-eveonline/pkg/sde"
+)
+
+type IndustryJobProjection struct {
+	Job              *esi.IndustryJob
+	Blueprint        *sde.Blueprint
+	ExpectedProducts []sde.TypeQuantity
+	CompletesAt      time.Time
+}
+
+func (p IndustryJobProjection) Remaining(now time.Time) time.Duration {
+	if now.After(p.CompletesAt) {
+		return 0
+	}
+	return p.CompletesAt.Sub(now)
+}
+
+// ProjectIndustryJobs joins each job to the blueprint it was installed from.  Paused jobs
+// are assumed to resume at now.  Jobs whose blueprint is not in blueprints, which is common with
+// partial SDE data, are returned separately instead of being projected.
+func ProjectIndustryJobs(
+	jobs esi.IndustryJobs,
+	blueprints sde.ProductBlueprintMap,
+	now time.Time,
+) ([]*IndustryJobProjection, esi.IndustryJobs) {
+	blueprintsByTypeID := blueprints.BlueprintsByTypeID()
+
+	projections := make([]*IndustryJobProjection, 0, len(jobs))
+	unknownBlueprints := make(esi.IndustryJobs, 0)
+	for _, job := range jobs {
+		blueprint, ok := blueprintsByTypeID[job.BlueprintTypeID]
+		if !ok {
+			unknownBlueprints = append(unknownBlueprints, job)
+			continue
+		}
+
+		completesAt := job.EndDate
+		if job.Status == esi.IndustryJobStatusPaused && job.PauseDate != nil {
+			completesAt = now.Add(job.EndDate.Sub(*job.PauseDate))
+		}
+
+		projections = append(projections, &IndustryJobProjection{
+			Job:              job,
+			Blueprint:        blueprint,
+			ExpectedProducts: expectedJobProducts(job, blueprint),
+			CompletesAt:      completesAt,
+		})
+	}
+
+	return projections, unknownBlueprints
+}
+
+func expectedJobProducts(job *esi.IndustryJob, blueprint *sde.Blueprint) []sde.TypeQuantity {
+	if job.ActivityID == eveonline.CopyingActivityID {
+		return []sde.TypeQuantity{{TypeID: blueprint.BlueprintTypeID, Quantity: job.Runs}}
+	}
+
+	activity := blueprint.Activities.Activity(job.ActivityID)
+	if activity == nil {
+		return []sde.TypeQuantity{}
+	}
+
+	expected := make([]sde.TypeQuantity, 0, len(activity.Products))
+	for _, product := range activity.Products {
+		if job.ProductTypeID != 0 && job.ActivityID == eveonline.InventionActivityID && product.TypeID != job.ProductTypeID {
+			continue
+		}
+		expected = append(expected, sde.TypeQuantity{
+			TypeID:      product.TypeID,
+			Quantity:    product.Quantity * job.Runs,
+			Probability: product.Probability,
+		})
+	}
+
+	return expected
+}
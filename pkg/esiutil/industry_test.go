@@ -0,0 +1,58 @@
+package esiutil
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/pequalsnp/go-eveonline/pkg/esi"
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+	"github.com/pequalsnp/go-eveonline/pkg/sde"
+	"github.com/stretchr/testify/assert"
+)
+
+func loadTestIndustryJobs(t *testing.T) esi.IndustryJobs {
+	contents, err := ioutil.ReadFile("../../test/testdata/industryJobs.json")
+	if err != nil {
+		t.Fatalf("Failed to read industry jobs test data: %v", err)
+	}
+	jobs := make(esi.IndustryJobs, 0)
+	err = json.Unmarshal(contents, &jobs)
+	if err != nil {
+		t.Fatalf("Failed to unmarshal industry jobs test data: %v", err)
+	}
+	return jobs
+}
+
+func loadTestBlueprints(t *testing.T) sde.ProductBlueprintMap {
+	contents, err := ioutil.ReadFile("../../test/testdata/blueprints.yaml")
+	if err != nil {
+		t.Fatalf("Failed to read blueprints YAML test data: %v", err)
+	}
+	blueprints, err := sde.ImportBlueprints(contents)
+	assert.Nil(t, err)
+	return blueprints
+}
+
+func TestProjectIndustryJobs(t *testing.T) {
+	now := time.Date(2026, 10, 19, 19, 0, 0, 0, time.UTC)
+	projections, unknownBlueprints := ProjectIndustryJobs(loadTestIndustryJobs(t), loadTestBlueprints(t), now)
+
+	assert.Len(t, unknownBlueprints, 1)
+	assert.Equal(t, eveonline.TypeID(999999), unknownBlueprints[0].BlueprintTypeID)
+	assert.Len(t, projections, 3)
+
+	manufacturing := projections[0]
+	assert.Equal(t, []sde.TypeQuantity{{TypeID: 587, Quantity: 10}}, manufacturing.ExpectedProducts)
+	assert.Equal(t, 9*time.Hour+40*time.Minute, manufacturing.Remaining(now))
+
+	// Paused two hours before the end, so two hours are left from now.
+	invention := projections[1]
+	assert.Equal(t, []sde.TypeQuantity{{TypeID: 11372, Quantity: 2, Probability: 0.34}}, invention.ExpectedProducts)
+	assert.Equal(t, now.Add(2*time.Hour), invention.CompletesAt)
+
+	copying := projections[2]
+	assert.Equal(t, []sde.TypeQuantity{{TypeID: 681, Quantity: 3}}, copying.ExpectedProducts)
+	assert.Equal(t, time.Duration(0), copying.Remaining(now))
+}
@@ -0,0 +1,236 @@
+package esiutil
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pequalsnp/go-eveonline/pkg/esi"
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+)
+
+type LocationKind string
+
+const (
+	StationLocation     LocationKind = "station"
+	StructureLocation   LocationKind = "structure"
+	SolarSystemLocation LocationKind = "solar_system"
+	ItemLocation        LocationKind = "item"
+	AssetSafetyLocation LocationKind = "asset_safety"
+)
+
+const (
+	assetSafetyLocationID = eveonline.LocationID(2004)
+	minSolarSystemID      = eveonline.LocationID(30000000)
+	maxSolarSystemID      = eveonline.LocationID(32999999)
+	minStationID          = eveonline.LocationID(60000000)
+	maxStationID          = eveonline.LocationID(63999999)
+	minStructureID        = eveonline.LocationID(1000000000000)
+)
+
+// How long a refused structure lookup is remembered before asking ESI again.  Ids that ESI does
+// not know as structures, usually items in the newer id ranges, are remembered for longer.
+// Other failures are not remembered.
+const (
+	DefaultForbiddenStructureTTL = time.Hour
+	DefaultMissingStructureTTL   = 24 * time.Hour
+)
+
+// ClassifyLocationID works out what a location id refers to from its id range alone.  Ids above
+// the structure threshold can also be items in newer id ranges; the resolver tells those apart.
+func ClassifyLocationID(locationID eveonline.LocationID) LocationKind {
+	switch {
+	case locationID == assetSafetyLocationID:
+		return AssetSafetyLocation
+	case locationID >= minSolarSystemID && locationID <= maxSolarSystemID:
+		return SolarSystemLocation
+	case locationID >= minStationID && locationID <= maxStationID:
+		return StationLocation
+	case locationID >= minStructureID:
+		return StructureLocation
+	}
+	return ItemLocation
+}
+
+type Location struct {
+	ID              eveonline.LocationID
+	Kind            LocationKind
+	Name            string
+	SystemID        eveonline.SystemID
+	ConstellationID eveonline.ConstellationID
+	RegionID        eveonline.RegionID
+	// ParentID is set for items and containers that were resolved through their parent.
+	ParentID eveonline.LocationID
+}
+
+type LocationCycleError struct {
+	Path []eveonline.LocationID
+}
+
+func (e LocationCycleError) Error() string {
+	return fmt.Sprintf("Location cycle detected: %v", e.Path)
+}
+
+type StructureSource interface {
+	GetStructure(authdClient *http.Client, structureID eveonline.StructureID) (*esi.Structure, error)
+}
+
+var _ StructureSource = (*esi.ESI)(nil)
+
+type structureFailure struct {
+	expiresAt time.Time
+	err       error
+}
+
+// LocationResolver turns location ids from assets, orders and jobs into a Location.  Structures
+// need an authenticated client with the structure read scope.  Refused and unknown structures
+// are cached separately so repeated lookups do not spend the ESI error budget.
+type LocationResolver struct {
+	Universe     UniverseProvider
+	Structures   StructureSource
+	AuthdClient  *http.Client
+	ForbiddenTTL time.Duration
+	MissingTTL   time.Duration
+
+	mutex         sync.Mutex
+	itemLocations map[eveonline.LocationID]eveonline.LocationID
+	structures    map[eveonline.StructureID]*Location
+	failed        map[eveonline.StructureID]structureFailure
+	now           func() time.Time
+}
+
+func NewLocationResolver(universe UniverseProvider, structures StructureSource, authdClient *http.Client) *LocationResolver {
+	return &LocationResolver{
+		Universe:      universe,
+		Structures:    structures,
+		AuthdClient:   authdClient,
+		ForbiddenTTL:  DefaultForbiddenStructureTTL,
+		MissingTTL:    DefaultMissingStructureTTL,
+		itemLocations: make(map[eveonline.LocationID]eveonline.LocationID),
+		structures:    make(map[eveonline.StructureID]*Location),
+		failed:        make(map[eveonline.StructureID]structureFailure),
+		now:           time.Now,
+	}
+}
+
+// AddAssets teaches the resolver where items are, so assets inside containers and ships resolve
+// to the station or structure holding them.
+func (r *LocationResolver) AddAssets(assets []*esi.Asset) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for _, asset := range assets {
+		r.itemLocations[eveonline.LocationID(asset.ItemID)] = asset.LocationID
+	}
+}
+
+// Resolve returns a LocationCycleError when items added with AddAssets contain each other.
+func (r *LocationResolver) Resolve(locationID eveonline.LocationID) (*Location, error) {
+	return r.resolve(locationID, nil)
+}
+
+func (r *LocationResolver) resolve(locationID eveonline.LocationID, path []eveonline.LocationID) (*Location, error) {
+	for _, visited := range path {
+		if visited == locationID {
+			return nil, LocationCycleError{Path: append(append([]eveonline.LocationID{}, path...), locationID)}
+		}
+	}
+
+	r.mutex.Lock()
+	parentID, isItem := r.itemLocations[locationID]
+	r.mutex.Unlock()
+	if isItem {
+		parent, err := r.resolve(parentID, append(path, locationID))
+		if err != nil {
+			return nil, err
+		}
+		return &Location{
+			ID:              locationID,
+			Kind:            ItemLocation,
+			SystemID:        parent.SystemID,
+			ConstellationID: parent.ConstellationID,
+			RegionID:        parent.RegionID,
+			ParentID:        parentID,
+		}, nil
+	}
+
+	switch ClassifyLocationID(locationID) {
+	case AssetSafetyLocation:
+		return &Location{ID: locationID, Kind: AssetSafetyLocation, Name: "Asset Safety"}, nil
+	case SolarSystemLocation:
+		return r.resolveSystem(locationID, SolarSystemLocation, "", eveonline.SystemID(locationID))
+	case StationLocation:
+		station, err := r.Universe.GetStation(eveonline.StationID(locationID))
+		if err != nil {
+			return nil, err
+		}
+		return r.resolveSystem(locationID, StationLocation, station.Name, station.SystemID)
+	case StructureLocation:
+		return r.resolveStructure(eveonline.StructureID(locationID))
+	}
+	return &Location{ID: locationID, Kind: ItemLocation}, nil
+}
+
+func (r *LocationResolver) resolveSystem(locationID eveonline.LocationID, kind LocationKind, name string, systemID eveonline.SystemID) (*Location, error) {
+	system, err := r.Universe.GetSystem(systemID)
+	if err != nil {
+		return nil, err
+	}
+	constellation, err := r.Universe.GetConstellation(system.ConstellationID)
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		name = system.Name
+	}
+
+	return &Location{
+		ID:              locationID,
+		Kind:            kind,
+		Name:            name,
+		SystemID:        system.ID,
+		ConstellationID: constellation.ID,
+		RegionID:        constellation.RegionID,
+	}, nil
+}
+
+func (r *LocationResolver) resolveStructure(structureID eveonline.StructureID) (*Location, error) {
+	r.mutex.Lock()
+	if location, ok := r.structures[structureID]; ok {
+		r.mutex.Unlock()
+		return location, nil
+	}
+	if failure, ok := r.failed[structureID]; ok && r.now().Before(failure.expiresAt) {
+		r.mutex.Unlock()
+		return nil, failure.err
+	}
+	r.mutex.Unlock()
+
+	structure, err := r.Structures.GetStructure(r.AuthdClient, structureID)
+	if err != nil {
+		ttl := time.Duration(0)
+		if _, ok := err.(esi.StructureAccessDeniedError); ok {
+			ttl = r.ForbiddenTTL
+		} else if isNotFound(err) {
+			ttl = r.MissingTTL
+		}
+		if ttl > 0 {
+			r.mutex.Lock()
+			r.failed[structureID] = structureFailure{expiresAt: r.now().Add(ttl), err: err}
+			r.mutex.Unlock()
+		}
+		return nil, err
+	}
+
+	location, err := r.resolveSystem(eveonline.LocationID(structureID), StructureLocation, structure.Name, structure.SystemID)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mutex.Lock()
+	r.structures[structureID] = location
+	delete(r.failed, structureID)
+	r.mutex.Unlock()
+
+	return location, nil
+}
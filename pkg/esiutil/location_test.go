@@ -0,0 +1,152 @@
+package esiutil
+
+import (
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/pequalsnp/go-eveonline/pkg/esi"
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+	"github.com/pequalsnp/go-eveonline/pkg/sde"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeStructureSource struct {
+	structures map[eveonline.StructureID]*esi.Structure
+	errors     map[eveonline.StructureID]error
+	calls      int
+}
+
+func (f *fakeStructureSource) GetStructure(authdClient *http.Client, structureID eveonline.StructureID) (*esi.Structure, error) {
+	f.calls++
+	if err, ok := f.errors[structureID]; ok {
+		return nil, err
+	}
+	structure, ok := f.structures[structureID]
+	if !ok {
+		return nil, esi.StructureAccessDeniedError{StructureID: structureID}
+	}
+	return structure, nil
+}
+
+func loadTestUniverseProvider(t *testing.T) *SDEUniverseProvider {
+	universe, err := sde.LoadUniverse("../../test/testdata/universe", map[int64]string{})
+	if err != nil {
+		t.Fatalf("Failed to load universe test data: %v", err)
+	}
+	contents, err := ioutil.ReadFile("../../test/testdata/staStations.yaml")
+	if err != nil {
+		t.Fatalf("Failed to read staStations YAML test data: %v", err)
+	}
+	stations, err := sde.ImportStations(contents)
+	assert.Nil(t, err)
+	universe.AddStations(stations)
+
+	return NewSDEUniverseProviderWithMap(sde.NewTypeStore(nil, nil, nil), universe)
+}
+
+func TestClassifyLocationID(t *testing.T) {
+	assert.Equal(t, AssetSafetyLocation, ClassifyLocationID(2004))
+	assert.Equal(t, SolarSystemLocation, ClassifyLocationID(30000142))
+	assert.Equal(t, StationLocation, ClassifyLocationID(60003760))
+	assert.Equal(t, StructureLocation, ClassifyLocationID(1022734985679))
+	assert.Equal(t, ItemLocation, ClassifyLocationID(987654321))
+}
+
+func TestLocationResolver(t *testing.T) {
+	structures := &fakeStructureSource{structures: map[eveonline.StructureID]*esi.Structure{
+		1022734985679: {ID: 1022734985679, Name: "Perimeter - Tranquility Trading Tower", SystemID: 30000144},
+	}}
+	resolver := NewLocationResolver(loadTestUniverseProvider(t), structures, nil)
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	resolver.now = func() time.Time { return now }
+
+	station, err := resolver.Resolve(60003760)
+	assert.Nil(t, err)
+	assert.Equal(t, &Location{
+		ID:              60003760,
+		Kind:            StationLocation,
+		Name:            "Jita IV - Moon 4 - Caldari Navy Assembly Plant",
+		SystemID:        30000142,
+		ConstellationID: 20000020,
+		RegionID:        10000002,
+	}, station)
+
+	system, err := resolver.Resolve(30000145)
+	assert.Nil(t, err)
+	assert.Equal(t, "NewCaldari", system.Name)
+	assert.Equal(t, eveonline.RegionID(10000002), system.RegionID)
+
+	structure, err := resolver.Resolve(1022734985679)
+	assert.Nil(t, err)
+	assert.Equal(t, StructureLocation, structure.Kind)
+	assert.Equal(t, eveonline.SystemID(30000144), structure.SystemID)
+	_, err = resolver.Resolve(1022734985679)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, structures.calls)
+
+	_, err = resolver.Resolve(1000000000001)
+	assert.Equal(t, esi.StructureAccessDeniedError{StructureID: 1000000000001}, err)
+	_, err = resolver.Resolve(1000000000001)
+	assert.Equal(t, esi.StructureAccessDeniedError{StructureID: 1000000000001}, err)
+	assert.Equal(t, 2, structures.calls)
+	now = now.Add(2 * time.Hour)
+	_, err = resolver.Resolve(1000000000001)
+	assert.NotNil(t, err)
+	assert.Equal(t, 3, structures.calls)
+
+	resolver.AddAssets([]*esi.Asset{
+		{ItemID: 1040000000001, LocationID: 1022734985679},
+		{ItemID: 1040000000002, LocationID: 1040000000001},
+	})
+	item, err := resolver.Resolve(1040000000002)
+	assert.Nil(t, err)
+	assert.Equal(t, ItemLocation, item.Kind)
+	assert.Equal(t, eveonline.LocationID(1040000000001), item.ParentID)
+	assert.Equal(t, eveonline.SystemID(30000144), item.SystemID)
+
+	safety, err := resolver.Resolve(2004)
+	assert.Nil(t, err)
+	assert.Equal(t, AssetSafetyLocation, safety.Kind)
+}
+
+func TestLocationResolverFailures(t *testing.T) {
+	notFound := esi.StatusError{URL: "https://esi.evetech.net/v2/universe/structures/1040000000001/", StatusCode: http.StatusNotFound}
+	structures := &fakeStructureSource{errors: map[eveonline.StructureID]error{1040000000001: notFound}}
+	resolver := NewLocationResolver(loadTestUniverseProvider(t), structures, nil)
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	resolver.now = func() time.Time { return now }
+
+	// An item id in the structure range is only looked up once per TTL.
+	_, err := resolver.Resolve(1040000000001)
+	assert.Equal(t, notFound, err)
+	_, err = resolver.Resolve(1040000000001)
+	assert.Equal(t, notFound, err)
+	assert.Equal(t, 1, structures.calls)
+	now = now.Add(DefaultMissingStructureTTL)
+	_, err = resolver.Resolve(1040000000001)
+	assert.Equal(t, notFound, err)
+	assert.Equal(t, 2, structures.calls)
+
+	// A server error is not remembered, the next lookup goes through.
+	badGateway := esi.StatusError{URL: "https://esi.evetech.net/v2/universe/structures/1022734985679/", StatusCode: http.StatusBadGateway}
+	structures.errors[1022734985679] = badGateway
+	structures.structures = map[eveonline.StructureID]*esi.Structure{
+		1022734985679: {ID: 1022734985679, Name: "Perimeter - Tranquility Trading Tower", SystemID: 30000144},
+	}
+	_, err = resolver.Resolve(1022734985679)
+	assert.Equal(t, badGateway, err)
+	delete(structures.errors, 1022734985679)
+	location, err := resolver.Resolve(1022734985679)
+	assert.Nil(t, err)
+	assert.Equal(t, eveonline.SystemID(30000144), location.SystemID)
+	assert.Equal(t, 4, structures.calls)
+
+	resolver.AddAssets([]*esi.Asset{
+		{ItemID: 1040000000002, LocationID: 1040000000003},
+		{ItemID: 1040000000003, LocationID: 1040000000002},
+	})
+	_, err = resolver.Resolve(1040000000002)
+	assert.Equal(t, LocationCycleError{Path: []eveonline.LocationID{1040000000002, 1040000000003, 1040000000002}}, err)
+}
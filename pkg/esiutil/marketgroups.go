@@ -0,0 +1,169 @@
+package esiutil
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/pequalsnp/go-eveonline/pkg/esi"
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+	"github.com/pequalsnp/go-eveonline/pkg/sde"
+)
+
+// MarketGroupSource is satisfied by *esi.ESI.
+type MarketGroupSource interface {
+	GetMarketGroupIDs(httpClient *http.Client) ([]eveonline.MarketGroupID, error)
+	GetMarketGroup(httpClient *http.Client, marketGroupID eveonline.MarketGroupID) (*esi.MarketGroup, error)
+}
+
+var _ MarketGroupSource = (*esi.ESI)(nil)
+
+type MarketGroupNode struct {
+	ID          eveonline.MarketGroupID
+	Name        string
+	Description string
+	// IconID is only known when the tree was loaded from the SDE.
+	IconID   int64
+	ParentID *eveonline.MarketGroupID
+	Parent   *MarketGroupNode
+	// Children are sorted by name, the order the in-game market browser shows them in.
+	Children []*MarketGroupNode
+	TypeIDs  []eveonline.TypeID
+}
+
+type MarketGroupTree struct {
+	groups map[eveonline.MarketGroupID]*MarketGroupNode
+	roots  []*MarketGroupNode
+}
+
+func NewMarketGroupTreeFromSDE(
+	marketGroups map[eveonline.MarketGroupID]*sde.MarketGroup,
+	types *sde.TypeStore,
+) *MarketGroupTree {
+	nodes := make([]*MarketGroupNode, 0, len(marketGroups))
+	for _, marketGroup := range marketGroups {
+		nodes = append(nodes, &MarketGroupNode{
+			ID:          marketGroup.ID,
+			Name:        marketGroup.Name.String(),
+			Description: marketGroup.Description.String(),
+			IconID:      marketGroup.IconID,
+			ParentID:    marketGroup.ParentGroupID,
+			TypeIDs:     types.TypeIDsInMarketGroup(marketGroup.ID),
+		})
+	}
+	return newMarketGroupTree(nodes)
+}
+
+func NewMarketGroupTreeFromESI(marketGroups []*esi.MarketGroup) *MarketGroupTree {
+	nodes := make([]*MarketGroupNode, 0, len(marketGroups))
+	for _, marketGroup := range marketGroups {
+		typeIDs := append([]eveonline.TypeID{}, marketGroup.TypeIDs...)
+		sort.Slice(typeIDs, func(i, j int) bool { return typeIDs[i] < typeIDs[j] })
+		nodes = append(nodes, &MarketGroupNode{
+			ID:          marketGroup.ID,
+			Name:        marketGroup.Name,
+			Description: marketGroup.Description,
+			ParentID:    marketGroup.ParentGroupID,
+			TypeIDs:     typeIDs,
+		})
+	}
+	return newMarketGroupTree(nodes)
+}
+
+// LoadMarketGroupTree fetches every market group one at a time, which takes a while on a cold
+// cache.
+func LoadMarketGroupTree(source MarketGroupSource, httpClient *http.Client) (*MarketGroupTree, error) {
+	marketGroupIDs, err := source.GetMarketGroupIDs(httpClient)
+	if err != nil {
+		return nil, err
+	}
+
+	marketGroups := make([]*esi.MarketGroup, 0, len(marketGroupIDs))
+	for _, marketGroupID := range marketGroupIDs {
+		marketGroup, err := source.GetMarketGroup(httpClient, marketGroupID)
+		if err != nil {
+			return nil, err
+		}
+		marketGroups = append(marketGroups, marketGroup)
+	}
+
+	return NewMarketGroupTreeFromESI(marketGroups), nil
+}
+
+// Groups whose parent is missing are treated as roots, so a partial load still forms a tree.
+func newMarketGroupTree(nodes []*MarketGroupNode) *MarketGroupTree {
+	tree := &MarketGroupTree{groups: make(map[eveonline.MarketGroupID]*MarketGroupNode)}
+	for _, node := range nodes {
+		tree.groups[node.ID] = node
+	}
+	for _, node := range nodes {
+		if node.ParentID != nil {
+			if parent, ok := tree.groups[*node.ParentID]; ok {
+				node.Parent = parent
+				parent.Children = append(parent.Children, node)
+				continue
+			}
+		}
+		tree.roots = append(tree.roots, node)
+	}
+
+	sortMarketGroupNodes(tree.roots)
+	for _, node := range nodes {
+		sortMarketGroupNodes(node.Children)
+	}
+	return tree
+}
+
+func sortMarketGroupNodes(nodes []*MarketGroupNode) {
+	sort.Slice(nodes, func(i, j int) bool {
+		if nodes[i].Name != nodes[j].Name {
+			return nodes[i].Name < nodes[j].Name
+		}
+		return nodes[i].ID < nodes[j].ID
+	})
+}
+
+func (t *MarketGroupTree) Roots() []*MarketGroupNode {
+	return t.roots
+}
+
+func (t *MarketGroupTree) Group(marketGroupID eveonline.MarketGroupID) (*MarketGroupNode, bool) {
+	node, ok := t.groups[marketGroupID]
+	return node, ok
+}
+
+// Path lists the groups from the root down to and including marketGroupID.
+func (t *MarketGroupTree) Path(marketGroupID eveonline.MarketGroupID) []*MarketGroupNode {
+	path := make([]*MarketGroupNode, 0)
+	for node, ok := t.groups[marketGroupID]; ok && node != nil; node = node.Parent {
+		path = append([]*MarketGroupNode{node}, path...)
+	}
+	return path
+}
+
+// AllTypeIDs lists the types in a market group and every group below it, sorted by id.
+func (t *MarketGroupTree) AllTypeIDs(marketGroupID eveonline.MarketGroupID) []eveonline.TypeID {
+	typeIDs := make([]eveonline.TypeID, 0)
+	node, ok := t.groups[marketGroupID]
+	if !ok {
+		return typeIDs
+	}
+	walkMarketGroups(node, 0, func(node *MarketGroupNode, depth int) {
+		typeIDs = append(typeIDs, node.TypeIDs...)
+	})
+	sort.Slice(typeIDs, func(i, j int) bool { return typeIDs[i] < typeIDs[j] })
+	return typeIDs
+}
+
+// Walk visits every group depth first in market browser order.  Roots have depth 0.
+func (t *MarketGroupTree) Walk(visit func(node *MarketGroupNode, depth int)) {
+	for _, root := range t.roots {
+		walkMarketGroups(root, 0, visit)
+	}
+}
+
+func walkMarketGroups(node *MarketGroupNode, depth int, visit func(node *MarketGroupNode, depth int)) {
+	visit(node, depth)
+	for _, child := range node.Children {
+		walkMarketGroups(child, depth+1, visit)
+	}
+}
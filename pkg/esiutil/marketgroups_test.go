@@ -0,0 +1,96 @@
+package esiutil
+
+import (
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/pequalsnp/go-eveonline/pkg/esi"
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+	"github.com/pequalsnp/go-eveonline/pkg/sde"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeMarketGroupSource struct {
+	marketGroups map[eveonline.MarketGroupID]*esi.MarketGroup
+}
+
+func (f *fakeMarketGroupSource) GetMarketGroupIDs(httpClient *http.Client) ([]eveonline.MarketGroupID, error) {
+	marketGroupIDs := make([]eveonline.MarketGroupID, 0)
+	for marketGroupID := range f.marketGroups {
+		marketGroupIDs = append(marketGroupIDs, marketGroupID)
+	}
+	return marketGroupIDs, nil
+}
+
+func (f *fakeMarketGroupSource) GetMarketGroup(httpClient *http.Client, marketGroupID eveonline.MarketGroupID) (*esi.MarketGroup, error) {
+	return f.marketGroups[marketGroupID], nil
+}
+
+func TestMarketGroupTreeFromSDE(t *testing.T) {
+	contents, err := ioutil.ReadFile("../../test/testdata/marketGroups.yaml")
+	if err != nil {
+		t.Fatalf("Failed to read marketGroups YAML test data: %v", err)
+	}
+	marketGroups, err := sde.ImportMarketGroups(contents)
+	assert.Nil(t, err)
+	contents, err = ioutil.ReadFile("../../test/testdata/typeIDs.yaml")
+	if err != nil {
+		t.Fatalf("Failed to read typeIDs YAML test data: %v", err)
+	}
+	types, err := sde.ImportTypes(contents)
+	assert.Nil(t, err)
+
+	tree := NewMarketGroupTreeFromSDE(marketGroups, sde.NewTypeStore(types, nil, nil))
+
+	roots := make([]string, 0)
+	for _, root := range tree.Roots() {
+		roots = append(roots, root.Name)
+	}
+	assert.Equal(t, []string{"Manufacture & Research", "Ships", "Skills"}, roots)
+
+	minerals, ok := tree.Group(1857)
+	assert.True(t, ok)
+	assert.Equal(t, int64(22), minerals.IconID)
+	assert.Equal(t, []eveonline.TypeID{34, 35}, minerals.TypeIDs)
+	assert.Equal(t, []eveonline.TypeID{34, 35}, tree.AllTypeIDs(475))
+	assert.Equal(t, []eveonline.TypeID{587}, tree.AllTypeIDs(4))
+	assert.Empty(t, tree.AllTypeIDs(1))
+
+	path := make([]eveonline.MarketGroupID, 0)
+	for _, node := range tree.Path(64) {
+		path = append(path, node.ID)
+	}
+	assert.Equal(t, []eveonline.MarketGroupID{4, 1361, 1364, 64}, path)
+
+	depths := make(map[eveonline.MarketGroupID]int)
+	tree.Walk(func(node *MarketGroupNode, depth int) {
+		depths[node.ID] = depth
+	})
+	assert.Len(t, depths, 9)
+	assert.Equal(t, 3, depths[64])
+	assert.Equal(t, 1, depths[369])
+}
+
+func TestLoadMarketGroupTree(t *testing.T) {
+	materials := eveonline.MarketGroupID(533)
+	missing := eveonline.MarketGroupID(475)
+	source := &fakeMarketGroupSource{marketGroups: map[eveonline.MarketGroupID]*esi.MarketGroup{
+		533:  {ID: 533, Name: "Materials", ParentGroupID: &missing},
+		1857: {ID: 1857, Name: "Minerals", ParentGroupID: &materials, TypeIDs: []eveonline.TypeID{35, 34}},
+		1032: {ID: 1032, Name: "Ice Products", ParentGroupID: &materials, TypeIDs: []eveonline.TypeID{16272}},
+	}}
+
+	tree, err := LoadMarketGroupTree(source, nil)
+	assert.Nil(t, err)
+	assert.Len(t, tree.Roots(), 1)
+	materialsNode, _ := tree.Group(533)
+	assert.Equal(t, "Ice Products", materialsNode.Children[0].Name)
+	assert.Equal(t, "Minerals", materialsNode.Children[1].Name)
+	assert.Equal(t, []eveonline.TypeID{34, 35, 16272}, tree.AllTypeIDs(533))
+
+	// An ESI error body is not mistaken for a market group.
+	failing := &esi.ESI{Cache: noCache{}, HttpClient: &http.Client{Transport: statusTransport{statusCode: http.StatusBadGateway}}}
+	_, err = LoadMarketGroupTree(failing, nil)
+	assert.Equal(t, esi.StatusError{URL: esi.MarketGroupsURL, StatusCode: http.StatusBadGateway}, err)
+}
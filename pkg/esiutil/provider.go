@@ -0,0 +1,211 @@
+package esiutil
+
+import (
+	"sort"
+
+	"github.com/pequalsnp/go-eveonline/pkg/esi"
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+	"github.com/pequalsnp/go-eveonline/pkg/sde"
+)
+
+type UniverseProvider interface {
+	TypeSource
+	GetStation(stationID eveonline.StationID) (*esi.Station, error)
+	GetSystem(systemID eveonline.SystemID) (*esi.System, error)
+	GetConstellation(constellationID eveonline.ConstellationID) (*esi.Constellation, error)
+	GetRegion(regionID eveonline.RegionID) (*esi.Region, error)
+}
+
+var _ UniverseProvider = (*esi.ESI)(nil)
+
+// SDEUniverseProvider serves universe lookups from the static data export without touching the
+// network.  Anything missing from the loaded data is reported as a NotFoundError.
+type SDEUniverseProvider struct {
+	SDETypeSource
+	Stations map[eveonline.StationID]*sde.Station
+	Universe *sde.Universe
+}
+
+func NewSDEUniverseProvider(types *sde.TypeStore, stations map[eveonline.StationID]*sde.Station) *SDEUniverseProvider {
+	return &SDEUniverseProvider{SDETypeSource: SDETypeSource{Store: types}, Stations: stations}
+}
+
+func NewSDEUniverseProviderWithMap(types *sde.TypeStore, universe *sde.Universe) *SDEUniverseProvider {
+	return &SDEUniverseProvider{SDETypeSource: SDETypeSource{Store: types}, Stations: universe.Stations, Universe: universe}
+}
+
+func (p *SDEUniverseProvider) GetStation(stationID eveonline.StationID) (*esi.Station, error) {
+	station, ok := p.Stations[stationID]
+	if !ok {
+		return nil, NotFoundError{Kind: "station", ID: int64(stationID)}
+	}
+
+	esiStation := &esi.Station{
+		ID:              station.ID,
+		Name:            station.Name,
+		SystemID:        station.SystemID,
+		ConstellationID: station.ConstellationID,
+		RegionID:        station.RegionID,
+	}
+	if p.Universe != nil {
+		if system, ok := p.Universe.Systems[station.SystemID]; ok {
+			esiStation.ConstellationID = system.ConstellationID
+			esiStation.RegionID = system.RegionID
+		}
+	}
+
+	return esiStation, nil
+}
+
+func (p *SDEUniverseProvider) GetSystem(systemID eveonline.SystemID) (*esi.System, error) {
+	if p.Universe == nil {
+		return nil, NotFoundError{Kind: "system", ID: int64(systemID)}
+	}
+	system, ok := p.Universe.Systems[systemID]
+	if !ok {
+		return nil, NotFoundError{Kind: "system", ID: int64(systemID)}
+	}
+
+	stargateIDs := make([]eveonline.StargateID, 0, len(system.Stargates))
+	for stargateID := range system.Stargates {
+		stargateIDs = append(stargateIDs, stargateID)
+	}
+	sort.Slice(stargateIDs, func(i, j int) bool { return stargateIDs[i] < stargateIDs[j] })
+
+	return &esi.System{
+		ID:              system.ID,
+		ConstellationID: system.ConstellationID,
+		Name:            system.Name,
+		SecurityStatus:  system.Security,
+		SecurityClass:   system.SecurityClass,
+		Position:        esi.Position{X: system.Center.X, Y: system.Center.Y, Z: system.Center.Z},
+		StargateIDs:     stargateIDs,
+		StationIDs:      system.StationIDs,
+	}, nil
+}
+
+func (p *SDEUniverseProvider) GetConstellation(constellationID eveonline.ConstellationID) (*esi.Constellation, error) {
+	if p.Universe == nil {
+		return nil, NotFoundError{Kind: "constellation", ID: int64(constellationID)}
+	}
+	constellation, ok := p.Universe.Constellations[constellationID]
+	if !ok {
+		return nil, NotFoundError{Kind: "constellation", ID: int64(constellationID)}
+	}
+
+	return &esi.Constellation{
+		ID:        constellation.ID,
+		RegionID:  constellation.RegionID,
+		Name:      constellation.Name,
+		SystemIDs: constellation.SystemIDs,
+	}, nil
+}
+
+func (p *SDEUniverseProvider) GetRegion(regionID eveonline.RegionID) (*esi.Region, error) {
+	if p.Universe == nil {
+		return nil, NotFoundError{Kind: "region", ID: int64(regionID)}
+	}
+	region, ok := p.Universe.Regions[regionID]
+	if !ok {
+		return nil, NotFoundError{Kind: "region", ID: int64(regionID)}
+	}
+
+	return &esi.Region{
+		ID:               region.ID,
+		Name:             region.Name,
+		ConstellationIDs: region.ConstellationIDs,
+	}, nil
+}
+
+// LayeredUniverseProvider asks each provider in turn, moving on only when a provider reports a
+// NotFoundError or ESI answers 404.  Put the SDE provider first and ESI last to use the network only for data the
+// SDE does not have yet.
+type LayeredUniverseProvider struct {
+	Providers []UniverseProvider
+}
+
+func NewLayeredUniverseProvider(providers ...UniverseProvider) *LayeredUniverseProvider {
+	return &LayeredUniverseProvider{Providers: providers}
+}
+
+// isNotFound reports whether a provider does not have an object, either from the SDE providers'
+// NotFoundError or an ESI 404.
+func isNotFound(err error) bool {
+	switch typedErr := err.(type) {
+	case NotFoundError:
+		return true
+	case esi.StatusError:
+		return typedErr.NotFound()
+	}
+	return false
+}
+
+func (p *LayeredUniverseProvider) GetType(typeID eveonline.TypeID) (*esi.Type, error) {
+	for _, provider := range p.Providers {
+		obj, err := provider.GetType(typeID)
+		if !isNotFound(err) {
+			return obj, err
+		}
+	}
+	return nil, NotFoundError{Kind: "type", ID: int64(typeID)}
+}
+
+func (p *LayeredUniverseProvider) GetGroup(groupID eveonline.GroupID) (*esi.Group, error) {
+	for _, provider := range p.Providers {
+		obj, err := provider.GetGroup(groupID)
+		if !isNotFound(err) {
+			return obj, err
+		}
+	}
+	return nil, NotFoundError{Kind: "group", ID: int64(groupID)}
+}
+
+func (p *LayeredUniverseProvider) GetCategory(categoryID eveonline.CategoryID) (*esi.Category, error) {
+	for _, provider := range p.Providers {
+		obj, err := provider.GetCategory(categoryID)
+		if !isNotFound(err) {
+			return obj, err
+		}
+	}
+	return nil, NotFoundError{Kind: "category", ID: int64(categoryID)}
+}
+
+func (p *LayeredUniverseProvider) GetStation(stationID eveonline.StationID) (*esi.Station, error) {
+	for _, provider := range p.Providers {
+		obj, err := provider.GetStation(stationID)
+		if !isNotFound(err) {
+			return obj, err
+		}
+	}
+	return nil, NotFoundError{Kind: "station", ID: int64(stationID)}
+}
+
+func (p *LayeredUniverseProvider) GetSystem(systemID eveonline.SystemID) (*esi.System, error) {
+	for _, provider := range p.Providers {
+		obj, err := provider.GetSystem(systemID)
+		if !isNotFound(err) {
+			return obj, err
+		}
+	}
+	return nil, NotFoundError{Kind: "system", ID: int64(systemID)}
+}
+
+func (p *LayeredUniverseProvider) GetConstellation(constellationID eveonline.ConstellationID) (*esi.Constellation, error) {
+	for _, provider := range p.Providers {
+		obj, err := provider.GetConstellation(constellationID)
+		if !isNotFound(err) {
+			return obj, err
+		}
+	}
+	return nil, NotFoundError{Kind: "constellation", ID: int64(constellationID)}
+}
+
+func (p *LayeredUniverseProvider) GetRegion(regionID eveonline.RegionID) (*esi.Region, error) {
+	for _, provider := range p.Providers {
+		obj, err := provider.GetRegion(regionID)
+		if !isNotFound(err) {
+			return obj, err
+		}
+	}
+	return nil, NotFoundError{Kind: "region", ID: int64(regionID)}
+}
@@ -0,0 +1,116 @@
+package esiutil
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/pequalsnp/go-eveonline/pkg/esi"
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+	"github.com/pequalsnp/go-eveonline/pkg/sde"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeUniverseProvider struct {
+	SDEUniverseProvider
+	systemIDs map[eveonline.SystemID]bool
+	calls     int
+}
+
+func (f *fakeUniverseProvider) GetType(typeID eveonline.TypeID) (*esi.Type, error) {
+	f.calls++
+	return &esi.Type{ID: typeID, Name: "From ESI"}, nil
+}
+
+func (f *fakeUniverseProvider) GetSystem(systemID eveonline.SystemID) (*esi.System, error) {
+	f.calls++
+	if !f.systemIDs[systemID] {
+		return nil, NotFoundError{Kind: "system", ID: int64(systemID)}
+	}
+	return &esi.System{ID: systemID, Name: "From fallback"}, nil
+}
+
+type noCache struct{}
+
+func (noCache) Put(key []byte, responsePage *esi.ResponsePage) error { return nil }
+func (noCache) Get(key []byte) (*esi.ResponsePage, error)            { return nil, nil }
+
+type statusTransport struct {
+	statusCode int
+}
+
+func (s statusTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: s.statusCode,
+		Header:     http.Header{},
+		Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"error":"not found"}`))),
+		Request:    request,
+	}, nil
+}
+
+func TestLayeredUniverseProviderFallsThroughESINotFound(t *testing.T) {
+	notFound := &esi.ESI{Cache: noCache{}, HttpClient: &http.Client{Transport: statusTransport{statusCode: http.StatusNotFound}}}
+	fallback := &fakeUniverseProvider{
+		SDEUniverseProvider: SDEUniverseProvider{SDETypeSource: SDETypeSource{Store: sde.NewTypeStore(nil, nil, nil)}},
+		systemIDs:           map[eveonline.SystemID]bool{31000005: true},
+	}
+
+	system, err := NewLayeredUniverseProvider(notFound, fallback).GetSystem(31000005)
+	assert.Nil(t, err)
+	assert.Equal(t, "From fallback", system.Name)
+	assert.Equal(t, 1, fallback.calls)
+
+	_, err = NewLayeredUniverseProvider(notFound).GetRegion(10000002)
+	assert.Equal(t, NotFoundError{Kind: "region", ID: 10000002}, err)
+
+	failing := &esi.ESI{Cache: noCache{}, HttpClient: &http.Client{Transport: statusTransport{statusCode: http.StatusBadGateway}}}
+	_, err = NewLayeredUniverseProvider(failing, fallback).GetSystem(30000142)
+	assert.Equal(t, esi.StatusError{URL: "https://esi.evetech.net/v4/universe/systems/30000142/", StatusCode: http.StatusBadGateway}, err)
+	assert.Equal(t, 1, fallback.calls)
+}
+
+func TestLayeredUniverseProvider(t *testing.T) {
+	offline := NewSDEUniverseProvider(
+		sde.NewTypeStore(
+			map[eveonline.TypeID]*sde.Type{587: {ID: 587, Name: sde.LocalizedString{"en": "Rifter"}}},
+			map[eveonline.GroupID]*sde.Group{},
+			map[eveonline.CategoryID]*sde.Category{},
+		),
+		map[eveonline.StationID]*sde.Station{
+			60003760: {ID: 60003760, Name: "Jita IV - Moon 4 - Caldari Navy Assembly Plant", SystemID: 30000142, ConstellationID: 20000020, RegionID: 10000002},
+		},
+	)
+	online := &fakeUniverseProvider{SDEUniverseProvider: SDEUniverseProvider{SDETypeSource: SDETypeSource{Store: sde.NewTypeStore(nil, nil, nil)}}}
+	layered := NewLayeredUniverseProvider(offline, online)
+
+	rifter, err := layered.GetType(587)
+	assert.Nil(t, err)
+	assert.Equal(t, "Rifter", rifter.Name)
+	assert.Equal(t, 0, online.calls)
+
+	newType, err := layered.GetType(99999)
+	assert.Nil(t, err)
+	assert.Equal(t, "From ESI", newType.Name)
+	assert.Equal(t, 1, online.calls)
+
+	station, err := layered.GetStation(60003760)
+	assert.Nil(t, err)
+	assert.Equal(t, eveonline.RegionID(10000002), station.RegionID)
+
+	_, err = layered.GetRegion(10000002)
+	assert.Equal(t, NotFoundError{Kind: "region", ID: 10000002}, err)
+
+	universe := sde.NewUniverse()
+	universe.AddRegion(&sde.Region{ID: 10000002, Name: "The Forge"})
+	universe.AddConstellation(&sde.Constellation{ID: 20000020, Name: "Kimotoro"}, 10000002)
+	offline.Universe = universe
+
+	region, err := layered.GetRegion(10000002)
+	assert.Nil(t, err)
+	assert.Equal(t, "The Forge", region.Name)
+	assert.Equal(t, []eveonline.ConstellationID{20000020}, region.ConstellationIDs)
+
+	_, err = layered.GetSystem(30000142)
+	assert.Equal(t, NotFoundError{Kind: "system", ID: 30000142}, err)
+}
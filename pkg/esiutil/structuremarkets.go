@@ -0,0 +1,72 @@
+package esiutil
+
+import (
+	"net/http"
+
+	"github.com/pequalsnp/go-eveonline/pkg/esi"
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+)
+
+type StructureOrdersSource interface {
+	GetStructureOrders(authdClient *http.Client, structureID eveonline.StructureID) (*esi.Orders, error)
+}
+
+var _ StructureOrdersSource = (*esi.ESI)(nil)
+
+// StructureOrdersReport lists the structures whose orders could not be fully collected.
+type StructureOrdersReport struct {
+	// Denied structures refused access to their market.
+	Denied []eveonline.StructureID
+	// Unresolved structures had their orders merged without a system, since their location could
+	// not be looked up; system aggregates and buy order ranges will not see them.
+	Unresolved []eveonline.StructureID
+	// Failed structures hit any other error and were left out.
+	Failed map[eveonline.StructureID]error
+}
+
+// CollectStructureOrders merges the markets of several structures into orders, usually the
+// regional orders from GetOrders.  Structure orders get their system filled in through resolver
+// so location and system aggregates work on them.  A structure that fails does not stop the
+// others; the report says which were skipped and why.
+func CollectStructureOrders(
+	source StructureOrdersSource,
+	resolver *LocationResolver,
+	authdClient *http.Client,
+	orders *esi.Orders,
+	structureIDs []eveonline.StructureID,
+) *StructureOrdersReport {
+	report := &StructureOrdersReport{
+		Denied:     make([]eveonline.StructureID, 0),
+		Unresolved: make([]eveonline.StructureID, 0),
+		Failed:     make(map[eveonline.StructureID]error),
+	}
+	for _, structureID := range structureIDs {
+		structureOrders, err := source.GetStructureOrders(authdClient, structureID)
+		if _, ok := err.(esi.StructureAccessDeniedError); ok {
+			report.Denied = append(report.Denied, structureID)
+			continue
+		}
+		if err != nil {
+			report.Failed[structureID] = err
+			continue
+		}
+
+		var systemID eveonline.SystemID
+		location, err := resolver.Resolve(eveonline.LocationID(structureID))
+		if _, ok := err.(esi.StructureAccessDeniedError); ok || isNotFound(err) {
+			report.Unresolved = append(report.Unresolved, structureID)
+		} else if err != nil {
+			report.Failed[structureID] = err
+			continue
+		} else {
+			systemID = location.SystemID
+		}
+		for _, order := range structureOrders.Orders {
+			order.SystemID = systemID
+		}
+
+		orders.Merge(structureOrders)
+	}
+
+	return report
+}
@@ -0,0 +1,82 @@
+package esiutil
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/pequalsnp/go-eveonline/pkg/esi"
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeStructureOrdersSource struct {
+	orders map[eveonline.StructureID][]*esi.Order
+	errors map[eveonline.StructureID]error
+}
+
+func (f *fakeStructureOrdersSource) GetStructureOrders(authdClient *http.Client, structureID eveonline.StructureID) (*esi.Orders, error) {
+	if err, ok := f.errors[structureID]; ok {
+		return nil, err
+	}
+	orders, ok := f.orders[structureID]
+	if !ok {
+		return nil, esi.StructureAccessDeniedError{StructureID: structureID}
+	}
+	return &esi.Orders{Orders: orders, ExpiresAt: time.Date(2026, 10, 19, 12, 5, 0, 0, time.UTC)}, nil
+}
+
+func TestCollectStructureOrders(t *testing.T) {
+	structureNotFound := esi.StatusError{URL: "https://esi.evetech.net/v1/markets/structures/1000000000004/", StatusCode: http.StatusNotFound}
+	badGateway := esi.StatusError{URL: "https://esi.evetech.net/v2/universe/structures/1000000000003/", StatusCode: http.StatusBadGateway}
+	structures := &fakeStructureSource{
+		structures: map[eveonline.StructureID]*esi.Structure{
+			1022734985679: {ID: 1022734985679, Name: "Perimeter - Tranquility Trading Tower", SystemID: 30000144},
+		},
+		errors: map[eveonline.StructureID]error{1000000000003: badGateway},
+	}
+	resolver := NewLocationResolver(loadTestUniverseProvider(t), structures, nil)
+	source := &fakeStructureOrdersSource{
+		orders: map[eveonline.StructureID][]*esi.Order{
+			1022734985679: {
+				{ID: 1, TypeID: 34, Price: 5.0, VolumeRemain: 10, LocationID: 1022734985679},
+				{ID: 3, TypeID: 34, Price: 4.8, VolumeRemain: 10, LocationID: 1022734985679},
+			},
+			1000000000002: {
+				{ID: 4, TypeID: 34, Price: 4.7, VolumeRemain: 10, LocationID: 1000000000002},
+			},
+			1000000000003: {
+				{ID: 5, TypeID: 34, Price: 4.6, VolumeRemain: 10, LocationID: 1000000000003},
+			},
+		},
+		errors: map[eveonline.StructureID]error{1000000000004: structureNotFound},
+	}
+	orders := &esi.Orders{RegionID: 10000002, ExpiresAt: time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC), Orders: []*esi.Order{
+		{ID: 1, TypeID: 34, Price: 5.0, VolumeRemain: 10, LocationID: 1022734985679, SystemID: 30000144},
+		{ID: 2, TypeID: 34, Price: 5.1, VolumeRemain: 10, LocationID: 60003760, SystemID: 30000142},
+	}}
+
+	// Failing structures are reported, the ones after them are still collected.
+	report := CollectStructureOrders(source, resolver, nil, orders, []eveonline.StructureID{
+		1000000000004, 1000000000003, 1022734985679, 1000000000001, 1000000000002,
+	})
+	assert.Equal(t, []eveonline.StructureID{1000000000001}, report.Denied)
+	assert.Equal(t, []eveonline.StructureID{1000000000002}, report.Unresolved)
+	assert.Equal(t, map[eveonline.StructureID]error{1000000000004: structureNotFound, 1000000000003: badGateway}, report.Failed)
+	assert.Len(t, orders.Orders, 4)
+	assert.Equal(t, eveonline.SystemID(30000144), orders.Orders[2].SystemID)
+	assert.Equal(t, eveonline.SystemID(0), orders.Orders[3].SystemID)
+	assert.Equal(t, time.Date(2026, 10, 19, 12, 5, 0, 0, time.UTC), orders.ExpiresAt)
+
+	aggregates := orders.SystemAggregates(30000144, nil)
+	assert.Equal(t, 4.8, aggregates[34].Sell.Min)
+	assert.Equal(t, 2, aggregates[34].Sell.OrderCount)
+
+	// The server error was not remembered, so the structure is collected once ESI recovers.
+	delete(structures.errors, 1000000000003)
+	structures.structures[1000000000003] = &esi.Structure{ID: 1000000000003, Name: "Jita - Fortizar", SystemID: 30000142}
+	report = CollectStructureOrders(source, resolver, nil, orders, []eveonline.StructureID{1000000000003})
+	assert.Empty(t, report.Failed)
+	assert.Len(t, orders.Orders, 5)
+	assert.Equal(t, eveonline.SystemID(30000142), orders.Orders[4].SystemID)
+}
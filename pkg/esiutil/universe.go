@@ -1,16 +1,84 @@
 package esiutil
 
 import (
+	"fmt"
+
 	"github.com/pequalsnp/go-eveonline/pkg/esi"
 	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+	"github.com/pequalsnp/go-eveonline/pkg/sde"
 )
 
+// TypeSource is satisfied by *esi.ESI and by SDETypeSource, so type lookups can be served from
+// either the network or the static data export.
+type TypeSource interface {
+	GetType(typeID eveonline.TypeID) (*esi.Type, error)
+	GetGroup(groupID eveonline.GroupID) (*esi.Group, error)
+	GetCategory(categoryID eveonline.CategoryID) (*esi.Category, error)
+}
+
+type NotFoundError struct {
+	Kind string
+	ID   int64
+}
+
+func (e NotFoundError) Error() string {
+	return fmt.Sprintf("No %s with id %d", e.Kind, e.ID)
+}
+
+type SDETypeSource struct {
+	Store *sde.TypeStore
+}
+
+func (s SDETypeSource) GetType(typeID eveonline.TypeID) (*esi.Type, error) {
+	typeObj, ok := s.Store.Type(typeID)
+	if !ok {
+		return nil, NotFoundError{Kind: "type", ID: int64(typeID)}
+	}
+
+	return &esi.Type{
+		ID:        typeObj.ID,
+		GroupID:   typeObj.GroupID,
+		Volume:    typeObj.Volume,
+		Name:      typeObj.Name.String(),
+		Published: typeObj.Published,
+	}, nil
+}
+
+func (s SDETypeSource) GetGroup(groupID eveonline.GroupID) (*esi.Group, error) {
+	group, ok := s.Store.Group(groupID)
+	if !ok {
+		return nil, NotFoundError{Kind: "group", ID: int64(groupID)}
+	}
+
+	return &esi.Group{
+		ID:         group.ID,
+		CategoryID: group.CategoryID,
+		TypeIDs:    s.Store.TypeIDsInGroup(groupID),
+		Name:       group.Name.String(),
+		Published:  group.Published,
+	}, nil
+}
+
+func (s SDETypeSource) GetCategory(categoryID eveonline.CategoryID) (*esi.Category, error) {
+	category, ok := s.Store.Category(categoryID)
+	if !ok {
+		return nil, NotFoundError{Kind: "category", ID: int64(categoryID)}
+	}
+
+	return &esi.Category{
+		ID:        category.ID,
+		GroupIDs:  s.Store.GroupIDsInCategory(categoryID),
+		Name:      category.Name.String(),
+		Published: category.Published,
+	}, nil
+}
+
 type result struct {
 	types []*esi.Type
 	err   error
 }
 
-func AllTypesForGroup(e *esi.ESI, groupID eveonline.GroupID) ([]*esi.Type, error) {
+func AllTypesForGroup(e TypeSource, groupID eveonline.GroupID) ([]*esi.Type, error) {
 	group, err := e.GetGroup(groupID)
 	if err != nil {
 		return nil, err
@@ -28,7 +96,7 @@ func AllTypesForGroup(e *esi.ESI, groupID eveonline.GroupID) ([]*esi.Type, error
 	return types, nil
 }
 
-func AllTypesForCategory(e *esi.ESI, categoryID eveonline.CategoryID) ([]*esi.Type, error) {
+func AllTypesForCategory(e TypeSource, categoryID eveonline.CategoryID) ([]*esi.Type, error) {
 	category, err := e.GetCategory(categoryID)
 	if err != nil {
 		return nil, err
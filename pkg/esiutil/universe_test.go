@@ -0,0 +1,39 @@
+package esiutil
+
+import (
+	"testing"
+
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+	"github.com/pequalsnp/go-eveonline/pkg/sde"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllTypesForCategory_SDE(t *testing.T) {
+	store := sde.NewTypeStore(
+		map[eveonline.TypeID]*sde.Type{
+			587:   {ID: 587, GroupID: 25, Name: sde.LocalizedString{"en": "Rifter"}, Published: true},
+			11371: {ID: 11371, GroupID: 324, Name: sde.LocalizedString{"en": "Wolf"}, Published: true},
+			34:    {ID: 34, GroupID: 18, Name: sde.LocalizedString{"en": "Tritanium"}, Published: true},
+		},
+		map[eveonline.GroupID]*sde.Group{
+			18:  {ID: 18, CategoryID: 4},
+			25:  {ID: 25, CategoryID: 6},
+			324: {ID: 324, CategoryID: 6},
+		},
+		map[eveonline.CategoryID]*sde.Category{
+			4: {ID: 4},
+			6: {ID: 6},
+		},
+	)
+
+	types, err := AllTypesForCategory(SDETypeSource{Store: store}, 6)
+	assert.Nil(t, err)
+	names := make([]string, 0)
+	for _, typeObj := range types {
+		names = append(names, typeObj.Name)
+	}
+	assert.ElementsMatch(t, []string{"Rifter", "Wolf"}, names)
+
+	_, err = AllTypesForCategory(SDETypeSource{Store: store}, 99)
+	assert.Equal(t, NotFoundError{Kind: "category", ID: 99}, err)
+}
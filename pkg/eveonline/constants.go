@@ -1,3 +1,12 @@
 package eveonline
 
 const SkillCategoryID = CategoryID(16)
+
+const (
+	ManufacturingActivityID              = IndustryActivityID(1)
+	ResearchTimeEfficiencyActivityID     = IndustryActivityID(3)
+	ResearchMaterialEfficiencyActivityID = IndustryActivityID(4)
+	CopyingActivityID                    = IndustryActivityID(5)
+	InventionActivityID                  = IndustryActivityID(8)
+	ReactionActivityID                   = IndustryActivityID(11)
+)
@@ -11,3 +11,11 @@ type LocationID int64
 type CharacterID int64
 type SkillID int64
 type CorporationID int64
+type IndustryActivityID int
+type MarketGroupID int64
+type MetaGroupID int64
+type DogmaAttributeID int64
+type DogmaEffectID int64
+type StargateID int64
+type StructureID int64
+type FactionID int64
@@ -0,0 +1,241 @@
+package fitting
+
+import (
+	"bufio"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+	"github.com/pequalsnp/go-eveonline/pkg/sde"
+)
+
+var eftHeaderRegexp = regexp.MustCompile(`^\[([^,\]]+),\s*(.*)\]$`)
+var eftQuantityRegexp = regexp.MustCompile(`^(.*\S)\s+x(\d+)$`)
+
+const eftOfflineSuffix = "/OFFLINE"
+
+// Empty racks keep their section so the blank line separated layout still lines up.
+var eftEmptyRacks = map[Slot]string{
+	LowSlot:  "[Empty Low slot]",
+	MedSlot:  "[Empty Med slot]",
+	HighSlot: "[Empty High slot]",
+}
+
+type UnknownTypesError struct {
+	Names []string
+}
+
+func (e UnknownTypesError) Error() string {
+	return fmt.Sprintf("Unknown type names: %s", strings.Join(e.Names, ", "))
+}
+
+// EFTLine is an item line of an EFT fit with its names not yet looked up.
+type EFTLine struct {
+	Name       string
+	ChargeName string
+	// Quantity is only given for drones, fighters and cargo; modules have none.
+	Quantity int
+	Offline  bool
+	// Section counts the blank line separated sections before the line.
+	Section int
+}
+
+// EFTText is an EFT fit as written.  ParseEFT resolves it into a Fit; ScanEFT is for callers
+// that resolve names some other way.
+type EFTText struct {
+	ShipName string
+	Name     string
+	Lines    []*EFTLine
+}
+
+// IsEFTHeader reports whether line opens an EFT fit, as in "[Rifter, Tackle]".
+func IsEFTHeader(line string) bool {
+	return eftHeaderRegexp.MatchString(strings.TrimSpace(line))
+}
+
+// ScanEFT splits a fit in the EFT text format into its ship and item lines.  Empty slots are
+// dropped but still count towards their section.
+func ScanEFT(text string) (*EFTText, error) {
+	scanner := bufio.NewScanner(strings.NewReader(text))
+
+	eft := &EFTText{Lines: make([]*EFTLine, 0)}
+	headerFound := false
+	section := 0
+	sectionHasContent := false
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !headerFound {
+			if line == "" {
+				continue
+			}
+			header := eftHeaderRegexp.FindStringSubmatch(line)
+			if header == nil {
+				return nil, fmt.Errorf("Invalid EFT header line: %s", line)
+			}
+			eft.ShipName = strings.TrimSpace(header[1])
+			eft.Name = strings.TrimSpace(header[2])
+			headerFound = true
+			continue
+		}
+
+		if line == "" {
+			if sectionHasContent {
+				section++
+				sectionHasContent = false
+			}
+			continue
+		}
+		sectionHasContent = true
+		if strings.HasPrefix(line, "[Empty ") {
+			continue
+		}
+
+		eftLine := &EFTLine{Section: section}
+		if strings.HasSuffix(line, eftOfflineSuffix) {
+			eftLine.Offline = true
+			line = strings.TrimSpace(strings.TrimSuffix(line, eftOfflineSuffix))
+		}
+
+		quantity := eftQuantityRegexp.FindStringSubmatch(line)
+		if quantity != nil {
+			line = quantity[1]
+			eftLine.Quantity, _ = strconv.Atoi(quantity[2])
+		}
+
+		parts := strings.SplitN(line, ",", 2)
+		eftLine.Name = strings.TrimSpace(parts[0])
+		if len(parts) == 2 {
+			eftLine.ChargeName = strings.TrimSpace(parts[1])
+		}
+		eft.Lines = append(eft.Lines, eftLine)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if !headerFound {
+		return nil, fmt.Errorf("Missing EFT header line")
+	}
+	return eft, nil
+}
+
+// ParseEFT parses a fit in the EFT text format.  Modules are placed in racks using their dogma
+// slot effects when dogma is given, otherwise by the order of the blank line separated sections.
+func ParseEFT(text string, types TypeLookup, dogma *sde.DogmaStore) (*Fit, error) {
+	eft, err := ScanEFT(text)
+	if err != nil {
+		return nil, err
+	}
+
+	unknownNames := make([]string, 0)
+	resolve := func(name string) eveonline.TypeID {
+		typeObj, ok := types.TypeByName(name)
+		if !ok {
+			unknownNames = append(unknownNames, name)
+			return 0
+		}
+		return typeObj.ID
+	}
+
+	fit := &Fit{Name: eft.Name, ShipTypeID: resolve(eft.ShipName), Items: make([]*Item, 0)}
+	positions := make(map[Slot]int)
+	for _, line := range eft.Lines {
+		item := &Item{Quantity: 1, Offline: line.Offline, TypeID: resolve(line.Name)}
+		if line.ChargeName != "" {
+			item.ChargeTypeID = resolve(line.ChargeName)
+		}
+		if item.TypeID == 0 {
+			continue
+		}
+
+		if line.Quantity != 0 {
+			item.Quantity = line.Quantity
+			item.Slot = bayFor(types, item.TypeID)
+		} else if rack, ok := rackFor(dogma, item.TypeID); ok {
+			item.Slot = rack
+		} else if dogma == nil && line.Section < len(racks) {
+			item.Slot = racks[line.Section]
+		} else {
+			item.Slot = bayFor(types, item.TypeID)
+		}
+
+		if item.Slot.IsRack() {
+			item.Position = positions[item.Slot]
+			positions[item.Slot]++
+		}
+		fit.Items = append(fit.Items, item)
+	}
+
+	if len(unknownNames) > 0 {
+		return nil, UnknownTypesError{Names: unknownNames}
+	}
+
+	return fit, nil
+}
+
+func typeName(types TypeLookup, typeID eveonline.TypeID) (string, error) {
+	typeObj, ok := types.Type(typeID)
+	if !ok {
+		return "", fmt.Errorf("Unknown type id %d", typeID)
+	}
+	return typeObj.Name.String(), nil
+}
+
+// EFT writes the fit in the EFT text format, racks first and then drones, fighters and cargo.
+func (f *Fit) EFT(types TypeLookup) (string, error) {
+	var builder strings.Builder
+
+	shipName, err := typeName(types, f.ShipTypeID)
+	if err != nil {
+		return "", err
+	}
+	fmt.Fprintf(&builder, "[%s, %s]\n", shipName, f.Name)
+
+	for _, rack := range racks {
+		items := f.ItemsInSlot(rack)
+		if len(items) == 0 {
+			if placeholder, ok := eftEmptyRacks[rack]; ok {
+				builder.WriteString(placeholder + "\n\n")
+			}
+			continue
+		}
+		for _, item := range items {
+			name, err := typeName(types, item.TypeID)
+			if err != nil {
+				return "", err
+			}
+			line := name
+			if item.ChargeTypeID != 0 {
+				chargeName, err := typeName(types, item.ChargeTypeID)
+				if err != nil {
+					return "", err
+				}
+				line = fmt.Sprintf("%s, %s", line, chargeName)
+			}
+			if item.Offline {
+				line = fmt.Sprintf("%s %s", line, eftOfflineSuffix)
+			}
+			builder.WriteString(line + "\n")
+		}
+		builder.WriteString("\n")
+	}
+
+	for _, bay := range []Slot{DroneBay, FighterBay, Cargo} {
+		items := f.ItemsInSlot(bay)
+		if len(items) == 0 {
+			continue
+		}
+		builder.WriteString("\n")
+		for _, item := range items {
+			name, err := typeName(types, item.TypeID)
+			if err != nil {
+				return "", err
+			}
+			fmt.Fprintf(&builder, "%s x%d\n", name, item.Quantity)
+		}
+	}
+
+	return strings.TrimRight(builder.String(), "\n") + "\n", nil
+}
@@ -0,0 +1,187 @@
+package fitting
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pequalsnp/go-eveonline/pkg/esi"
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+	"github.com/pequalsnp/go-eveonline/pkg/sde"
+)
+
+type Slot string
+
+const (
+	LowSlot       = Slot("LoSlot")
+	MedSlot       = Slot("MedSlot")
+	HighSlot      = Slot("HiSlot")
+	RigSlot       = Slot("RigSlot")
+	SubsystemSlot = Slot("SubSystemSlot")
+	DroneBay      = Slot("DroneBay")
+	FighterBay    = Slot("FighterBay")
+	Cargo         = Slot("Cargo")
+)
+
+// Racks are listed in the order EFT writes them.
+var racks = []Slot{LowSlot, MedSlot, HighSlot, RigSlot, SubsystemSlot}
+
+func (s Slot) IsRack() bool {
+	for _, rack := range racks {
+		if s == rack {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	droneCategoryID   = eveonline.CategoryID(18)
+	fighterCategoryID = eveonline.CategoryID(87)
+)
+
+type Item struct {
+	TypeID eveonline.TypeID
+	Slot   Slot
+	// Position is the index within a rack; it is zero for bays and cargo.
+	Position     int
+	Quantity     int
+	ChargeTypeID eveonline.TypeID
+	Offline      bool
+}
+
+type Fit struct {
+	Name        string
+	Description string
+	ShipTypeID  eveonline.TypeID
+	Items       []*Item
+}
+
+func (f *Fit) ItemsInSlot(slot Slot) []*Item {
+	items := make([]*Item, 0)
+	for _, item := range f.Items {
+		if item.Slot == slot {
+			items = append(items, item)
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].Position < items[j].Position })
+	return items
+}
+
+// TypeLookup is satisfied by *sde.TypeStore.
+type TypeLookup interface {
+	Type(typeID eveonline.TypeID) (*sde.Type, bool)
+	TypeByName(name string) (*sde.Type, bool)
+	Group(groupID eveonline.GroupID) (*sde.Group, bool)
+}
+
+func categoryOf(types TypeLookup, typeID eveonline.TypeID) eveonline.CategoryID {
+	typeObj, ok := types.Type(typeID)
+	if !ok {
+		return 0
+	}
+	group, ok := types.Group(typeObj.GroupID)
+	if !ok {
+		return 0
+	}
+	return group.CategoryID
+}
+
+func bayFor(types TypeLookup, typeID eveonline.TypeID) Slot {
+	switch categoryOf(types, typeID) {
+	case droneCategoryID:
+		return DroneBay
+	case fighterCategoryID:
+		return FighterBay
+	}
+	return Cargo
+}
+
+// rackFor uses the slot effects from dogma to decide where a module is fitted.
+func rackFor(dogma *sde.DogmaStore, typeID eveonline.TypeID) (Slot, bool) {
+	if dogma == nil || !dogma.HasType(typeID) {
+		return "", false
+	}
+
+	switch {
+	case dogma.TypeHasEffect(typeID, sde.LoPowerEffectID):
+		return LowSlot, true
+	case dogma.TypeHasEffect(typeID, sde.MedPowerEffectID):
+		return MedSlot, true
+	case dogma.TypeHasEffect(typeID, sde.HiPowerEffectID):
+		return HighSlot, true
+	case dogma.TypeHasEffect(typeID, sde.RigSlotEffectID):
+		return RigSlot, true
+	case dogma.TypeHasEffect(typeID, sde.SubSystemEffectID):
+		return SubsystemSlot, true
+	}
+	return "", false
+}
+
+func FromESI(fitting *esi.Fitting) (*Fit, error) {
+	fit := &Fit{
+		Name:        fitting.Name,
+		Description: fitting.Description,
+		ShipTypeID:  fitting.ShipTypeID,
+		Items:       make([]*Item, 0, len(fitting.Items)),
+	}
+
+	for _, esiItem := range fitting.Items {
+		slot, position, err := parseFlag(esiItem.Flag)
+		if err != nil {
+			return nil, err
+		}
+		fit.Items = append(fit.Items, &Item{
+			TypeID:   esiItem.TypeID,
+			Slot:     slot,
+			Position: position,
+			Quantity: esiItem.Quantity,
+		})
+	}
+
+	return fit, nil
+}
+
+func parseFlag(flag string) (Slot, int, error) {
+	for _, rack := range racks {
+		if strings.HasPrefix(flag, string(rack)) {
+			position, err := strconv.Atoi(strings.TrimPrefix(flag, string(rack)))
+			if err != nil {
+				return "", 0, fmt.Errorf("Invalid fitting flag %s", flag)
+			}
+			return rack, position, nil
+		}
+	}
+
+	switch Slot(flag) {
+	case DroneBay, FighterBay, Cargo:
+		return Slot(flag), 0, nil
+	}
+	return "", 0, fmt.Errorf("Unknown fitting flag %s", flag)
+}
+
+// ESIFitting builds the body for creating the fit through ESI.  ESI fittings have no notion of
+// loaded charges or offline modules, so those are not carried over.
+func (f *Fit) ESIFitting() *esi.Fitting {
+	fitting := &esi.Fitting{
+		Name:        f.Name,
+		Description: f.Description,
+		ShipTypeID:  f.ShipTypeID,
+		Items:       make([]*esi.FittingItem, 0, len(f.Items)),
+	}
+
+	for _, item := range f.Items {
+		flag := string(item.Slot)
+		if item.Slot.IsRack() {
+			flag = fmt.Sprintf("%s%d", item.Slot, item.Position)
+		}
+		fitting.Items = append(fitting.Items, &esi.FittingItem{
+			Flag:     flag,
+			Quantity: item.Quantity,
+			TypeID:   item.TypeID,
+		})
+	}
+
+	return fitting
+}
@@ -0,0 +1,149 @@
+package fitting
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/pequalsnp/go-eveonline/pkg/esi"
+	"github.com/pequalsnp/go-eveonline/pkg/sde"
+	"github.com/stretchr/testify/assert"
+)
+
+func loadTestData(t *testing.T) (*sde.TypeStore, *sde.DogmaStore) {
+	readFile := func(name string) []byte {
+		contents, err := ioutil.ReadFile("../../test/testdata/" + name)
+		if err != nil {
+			t.Fatalf("Failed to read %s test data: %v", name, err)
+		}
+		return contents
+	}
+
+	types, err := sde.ImportTypes(readFile("typeIDs.yaml"))
+	assert.Nil(t, err)
+	groups, err := sde.ImportGroups(readFile("groupIDs.yaml"))
+	assert.Nil(t, err)
+	categories, err := sde.ImportCategories(readFile("categoryIDs.yaml"))
+	assert.Nil(t, err)
+	attributes, err := sde.ImportDogmaAttributes(readFile("dogmaAttributes.yaml"))
+	assert.Nil(t, err)
+	effects, err := sde.ImportDogmaEffects(readFile("dogmaEffects.yaml"))
+	assert.Nil(t, err)
+	typeDogma, err := sde.ImportTypeDogma(readFile("typeDogma.yaml"))
+	assert.Nil(t, err)
+
+	return sde.NewTypeStore(types, groups, categories), sde.NewDogmaStore(attributes, effects, typeDogma)
+}
+
+const rifterEFT = `[Rifter, Tackle Rifter]
+Damage Control I
+
+1MN Afterburner I
+
+200mm AutoCannon I, EMP S
+200mm AutoCannon I, EMP S
+[Empty High slot]
+
+Small Projectile Burst Aerator I
+
+
+Warrior I x2
+
+EMP S x1000
+`
+
+func TestParseEFT(t *testing.T) {
+	types, dogma := loadTestData(t)
+
+	fit, err := ParseEFT(rifterEFT, types, dogma)
+	assert.Nil(t, err)
+	assert.Equal(t, "Tackle Rifter", fit.Name)
+	assert.EqualValues(t, 587, fit.ShipTypeID)
+
+	assert.Len(t, fit.ItemsInSlot(LowSlot), 1)
+	assert.Len(t, fit.ItemsInSlot(MedSlot), 1)
+	highs := fit.ItemsInSlot(HighSlot)
+	assert.Len(t, highs, 2)
+	assert.EqualValues(t, 185, highs[0].ChargeTypeID)
+	assert.Equal(t, 1, highs[1].Position)
+	assert.Len(t, fit.ItemsInSlot(RigSlot), 1)
+	assert.Equal(t, 2, fit.ItemsInSlot(DroneBay)[0].Quantity)
+	assert.Equal(t, 1000, fit.ItemsInSlot(Cargo)[0].Quantity)
+
+	withoutDogma, err := ParseEFT(rifterEFT, types, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, fit, withoutDogma)
+
+	_, err = ParseEFT("[Rifter, Bad]\nNot A Module\n", types, dogma)
+	assert.Equal(t, UnknownTypesError{Names: []string{"Not A Module"}}, err)
+}
+
+func TestEFTRoundTrip(t *testing.T) {
+	types, dogma := loadTestData(t)
+
+	fit, err := ParseEFT(rifterEFT, types, dogma)
+	assert.Nil(t, err)
+	eft, err := fit.EFT(types)
+	assert.Nil(t, err)
+
+	reparsed, err := ParseEFT(eft, types, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, fit, reparsed)
+}
+
+func TestESIRoundTrip(t *testing.T) {
+	types, dogma := loadTestData(t)
+
+	fit, err := ParseEFT(rifterEFT, types, dogma)
+	assert.Nil(t, err)
+	esiFitting := fit.ESIFitting()
+	assert.Equal(t, &esi.FittingItem{Flag: "HiSlot1", Quantity: 1, TypeID: 2873}, esiFitting.Items[3])
+
+	fromESI, err := FromESI(esiFitting)
+	assert.Nil(t, err)
+	assert.Equal(t, fit.ItemsInSlot(HighSlot)[1].Position, fromESI.ItemsInSlot(HighSlot)[1].Position)
+	assert.Len(t, fromESI.Items, len(fit.Items))
+
+	_, err = FromESI(&esi.Fitting{Items: []*esi.FittingItem{{Flag: "Nowhere"}}})
+	assert.NotNil(t, err)
+}
+
+func TestValidate(t *testing.T) {
+	types, dogma := loadTestData(t)
+
+	fit, err := ParseEFT(rifterEFT, types, dogma)
+	assert.Nil(t, err)
+	problems, err := Validate(fit, dogma)
+	assert.Nil(t, err)
+	assert.Empty(t, problems)
+
+	overfit, err := ParseEFT(`[Rifter, Overfit]
+Damage Control I
+Damage Control I
+Damage Control I
+Damage Control I
+Damage Control I
+Damage Control I
+Damage Control I
+
+200mm AutoCannon I
+200mm AutoCannon I
+200mm AutoCannon I
+200mm AutoCannon I
+
+Small Projectile Burst Aerator I
+Small Projectile Burst Aerator I
+Small Projectile Burst Aerator I
+Small Projectile Burst Aerator I
+Small Projectile Burst Aerator I
+`, types, dogma)
+	assert.Nil(t, err)
+	problems, err = Validate(overfit, dogma)
+	assert.Nil(t, err)
+	assert.Equal(t, []ValidationError{
+		{Resource: "low slots", Used: 7, Available: 3},
+		{Resource: "rig slots", Used: 5, Available: 3},
+		{Resource: "turret hardpoints", Used: 4, Available: 3},
+		{Resource: "CPU", Used: 172, Available: 130},
+		{Resource: "calibration", Used: 500, Available: 400},
+	}, problems)
+}
@@ -0,0 +1,88 @@
+package fitting
+
+import (
+	"fmt"
+
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+	"github.com/pequalsnp/go-eveonline/pkg/sde"
+)
+
+type ValidationError struct {
+	Resource  string
+	Used      float64
+	Available float64
+}
+
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("%s: using %v of %v", e.Resource, e.Used, e.Available)
+}
+
+// Validate checks the fit against the ship's unmodified dogma attributes.  Skill and module
+// bonuses to CPU and powergrid are not applied, so a fit that only works with fitting skills will
+// be reported as over budget.
+func Validate(fit *Fit, dogma *sde.DogmaStore) ([]ValidationError, error) {
+	if !dogma.HasType(fit.ShipTypeID) {
+		return nil, fmt.Errorf("No dogma attributes for ship type %d", fit.ShipTypeID)
+	}
+
+	shipAttribute := func(attributeID eveonline.DogmaAttributeID) float64 {
+		value, _ := dogma.TypeAttribute(fit.ShipTypeID, attributeID)
+		return value
+	}
+	itemAttribute := func(typeID eveonline.TypeID, attributeID eveonline.DogmaAttributeID) float64 {
+		value, _ := dogma.TypeAttribute(typeID, attributeID)
+		return value
+	}
+
+	var cpu, power, calibration, turrets, launchers float64
+	problems := make([]ValidationError, 0)
+	for _, item := range fit.Items {
+		if !item.Slot.IsRack() {
+			continue
+		}
+		if dogma.TypeHasEffect(item.TypeID, sde.TurretFittedEffectID) {
+			turrets++
+		}
+		if dogma.TypeHasEffect(item.TypeID, sde.LauncherFittedEffectID) {
+			launchers++
+		}
+		if item.Slot == RigSlot {
+			calibration += itemAttribute(item.TypeID, sde.UpgradeCostAttributeID)
+			rigSize := itemAttribute(item.TypeID, sde.RigSizeAttributeID)
+			shipRigSize := shipAttribute(sde.RigSizeAttributeID)
+			if rigSize != shipRigSize {
+				problems = append(problems, ValidationError{Resource: "rig size", Used: rigSize, Available: shipRigSize})
+			}
+			continue
+		}
+		if item.Offline {
+			continue
+		}
+		cpu += itemAttribute(item.TypeID, sde.CPUAttributeID)
+		power += itemAttribute(item.TypeID, sde.PowerAttributeID)
+	}
+
+	limits := []struct {
+		resource  string
+		used      float64
+		available float64
+	}{
+		{"high slots", float64(len(fit.ItemsInSlot(HighSlot))), shipAttribute(sde.HiSlotsAttributeID)},
+		{"medium slots", float64(len(fit.ItemsInSlot(MedSlot))), shipAttribute(sde.MedSlotsAttributeID)},
+		{"low slots", float64(len(fit.ItemsInSlot(LowSlot))), shipAttribute(sde.LowSlotsAttributeID)},
+		{"rig slots", float64(len(fit.ItemsInSlot(RigSlot))), shipAttribute(sde.RigSlotsAttributeID)},
+		{"subsystem slots", float64(len(fit.ItemsInSlot(SubsystemSlot))), shipAttribute(sde.MaxSubSystemsAttributeID)},
+		{"turret hardpoints", turrets, shipAttribute(sde.TurretSlotsLeftAttributeID)},
+		{"launcher hardpoints", launchers, shipAttribute(sde.LauncherSlotsLeftAttributeID)},
+		{"CPU", cpu, shipAttribute(sde.CPUOutputAttributeID)},
+		{"powergrid", power, shipAttribute(sde.PowerOutputAttributeID)},
+		{"calibration", calibration, shipAttribute(sde.UpgradeCapacityAttributeID)},
+	}
+	for _, limit := range limits {
+		if limit.used > limit.available {
+			problems = append(problems, ValidationError{Resource: limit.resource, Used: limit.used, Available: limit.available})
+		}
+	}
+
+	return problems, nil
+}
@@ -0,0 +1,117 @@
+package industry
+
+import (
+	"fmt"
+
+	"github.com/pequalsnp/go-eveonline/pkg/esi"
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+	"github.com/pequalsnp/go-eveonline/pkg/sde"
+)
+
+const (
+	SCCSurchargeRate       = 0.04
+	NPCStationTaxRate      = 0.0025
+	researchJobValueFactor = 0.02
+)
+
+type FeeLocation struct {
+	CostIndex        float64
+	FacilityTaxRate  float64
+	SCCSurchargeRate float64
+	Structure        Structure
+}
+
+func NewFeeLocation(
+	costIndices esi.IndustryCostIndices,
+	systemID eveonline.SystemID,
+	activityID eveonline.IndustryActivityID,
+	facilityTaxRate float64,
+	structure Structure,
+) FeeLocation {
+	return FeeLocation{
+		CostIndex:        costIndices.CostIndex(systemID, activityID),
+		FacilityTaxRate:  facilityTaxRate,
+		SCCSurchargeRate: SCCSurchargeRate,
+		Structure:        structure,
+	}
+}
+
+type JobFees struct {
+	EstimatedItemValue float64
+	SystemCost         float64
+	FacilityTax        float64
+	SCCSurcharge       float64
+	Total              float64
+}
+
+// EstimatedItemValue prices the unmodified blueprint inputs at their adjusted price.  Copying and
+// invention jobs are valued at 2% of the manufacturing value per run.  ME and TE research cost more
+// for every level researched, which is not modelled, so only single run quotes for the first level
+// are supported and more runs are an error.
+func EstimatedItemValue(
+	blueprint *sde.Blueprint,
+	activityID eveonline.IndustryActivityID,
+	runs int,
+	prices esi.MarketPrices,
+) (float64, error) {
+	valuedActivityID := activityID
+	factor := 1.0
+	switch activityID {
+	case eveonline.ManufacturingActivityID, eveonline.ReactionActivityID:
+	case eveonline.CopyingActivityID, eveonline.InventionActivityID:
+		valuedActivityID = eveonline.ManufacturingActivityID
+		factor = researchJobValueFactor
+	case eveonline.ResearchMaterialEfficiencyActivityID, eveonline.ResearchTimeEfficiencyActivityID:
+		if runs > 1 {
+			return 0.0, fmt.Errorf("Research quotes are only supported for the first level, not %d runs", runs)
+		}
+		valuedActivityID = eveonline.ManufacturingActivityID
+		factor = researchJobValueFactor
+	default:
+		return 0.0, fmt.Errorf("Unknown industry activity %d", activityID)
+	}
+
+	activity := blueprint.Activities.Activity(valuedActivityID)
+	if activity == nil {
+		return 0.0, fmt.Errorf("Blueprint %d does not support activity %d", blueprint.BlueprintTypeID, valuedActivityID)
+	}
+
+	value := 0.0
+	for _, material := range activity.Materials {
+		price, ok := prices[material.TypeID]
+		if !ok {
+			continue
+		}
+		value += float64(material.Quantity) * price.AdjustedPrice
+	}
+
+	return value * factor * float64(runs), nil
+}
+
+func EstimateJobFees(
+	blueprint *sde.Blueprint,
+	activityID eveonline.IndustryActivityID,
+	runs int,
+	prices esi.MarketPrices,
+	location FeeLocation,
+) (*JobFees, error) {
+	eiv, err := EstimatedItemValue(blueprint, activityID, runs, prices)
+	if err != nil {
+		return nil, err
+	}
+
+	systemCost := eiv * location.CostIndex
+	if location.Structure.appliesTo(activityID) {
+		systemCost *= 1 - location.Structure.CostBonus
+	}
+
+	fees := &JobFees{
+		EstimatedItemValue: eiv,
+		SystemCost:         systemCost,
+		FacilityTax:        eiv * location.FacilityTaxRate,
+		SCCSurcharge:       eiv * location.SCCSurchargeRate,
+	}
+	fees.Total = fees.SystemCost + fees.FacilityTax + fees.SCCSurcharge
+
+	return fees, nil
+}
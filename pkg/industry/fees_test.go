@@ -0,0 +1,91 @@
+package industry
+
+import (
+	"testing"
+
+	"github.com/pequalsnp/go-eveonline/pkg/esi"
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+	"github.com/pequalsnp/go-eveonline/pkg/sde"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimateJobFees(t *testing.T) {
+	blueprint := &sde.Blueprint{
+		BlueprintTypeID: 691,
+		Activities: &sde.Activities{
+			Manufacturing: &sde.Manufacturing{
+				Materials: []sde.TypeQuantity{{TypeID: 34, Quantity: 1000}, {TypeID: 35, Quantity: 100}},
+				Products:  []sde.TypeQuantity{{TypeID: 587, Quantity: 1}},
+			},
+			Copying: &sde.Copying{TimeInSeconds: 4800},
+		},
+	}
+	prices := esi.MarketPrices{
+		34: &esi.MarketPrice{TypeID: 34, AveragePrice: 6.0, AdjustedPrice: 5.0},
+		35: &esi.MarketPrice{TypeID: 35, AveragePrice: 12.0, AdjustedPrice: 10.0},
+	}
+	costIndices := esi.IndustryCostIndices{30000142: {eveonline.ManufacturingActivityID: 0.05, eveonline.CopyingActivityID: 0.02}}
+
+	tests := []struct {
+		name       string
+		activityID eveonline.IndustryActivityID
+		runs       int
+		location   FeeLocation
+		expected   JobFees
+	}{
+		{
+			name:       "npc station",
+			activityID: eveonline.ManufacturingActivityID,
+			runs:       2,
+			location:   NewFeeLocation(costIndices, 30000142, eveonline.ManufacturingActivityID, NPCStationTaxRate, NPCStation),
+			expected:   JobFees{EstimatedItemValue: 12000, SystemCost: 600, FacilityTax: 30, SCCSurcharge: 480, Total: 1110},
+		},
+		{
+			name:       "sotiyo role bonus",
+			activityID: eveonline.ManufacturingActivityID,
+			runs:       1,
+			location:   NewFeeLocation(costIndices, 30000142, eveonline.ManufacturingActivityID, 0.01, Sotiyo),
+			expected:   JobFees{EstimatedItemValue: 6000, SystemCost: 285, FacilityTax: 60, SCCSurcharge: 240, Total: 585},
+		},
+		{
+			name:       "copying",
+			activityID: eveonline.CopyingActivityID,
+			runs:       10,
+			location:   NewFeeLocation(costIndices, 30000142, eveonline.CopyingActivityID, 0, NPCStation),
+			expected:   JobFees{EstimatedItemValue: 1200, SystemCost: 24, SCCSurcharge: 48, Total: 72},
+		},
+		{
+			name:       "first level of material research",
+			activityID: eveonline.ResearchMaterialEfficiencyActivityID,
+			runs:       1,
+			location:   NewFeeLocation(costIndices, 30000142, eveonline.CopyingActivityID, 0, NPCStation),
+			expected:   JobFees{EstimatedItemValue: 120, SystemCost: 2.4, SCCSurcharge: 4.8, Total: 7.2},
+		},
+	}
+
+	for _, test := range tests {
+		fees, err := EstimateJobFees(blueprint, test.activityID, test.runs, prices, test.location)
+		assert.Nil(t, err, test.name)
+		assert.InDelta(t, test.expected.EstimatedItemValue, fees.EstimatedItemValue, 1e-6, test.name)
+		assert.InDelta(t, test.expected.SystemCost, fees.SystemCost, 1e-6, test.name)
+		assert.InDelta(t, test.expected.FacilityTax, fees.FacilityTax, 1e-6, test.name)
+		assert.InDelta(t, test.expected.SCCSurcharge, fees.SCCSurcharge, 1e-6, test.name)
+		assert.InDelta(t, test.expected.Total, fees.Total, 1e-6, test.name)
+	}
+}
+
+func TestEstimatedItemValueRejectsMultiLevelResearch(t *testing.T) {
+	blueprint := &sde.Blueprint{
+		BlueprintTypeID: 691,
+		Activities: &sde.Activities{
+			Manufacturing: &sde.Manufacturing{Materials: []sde.TypeQuantity{{TypeID: 34, Quantity: 1000}}},
+		},
+	}
+	prices := esi.MarketPrices{34: &esi.MarketPrice{TypeID: 34, AdjustedPrice: 5.0}}
+
+	_, err := EstimatedItemValue(blueprint, eveonline.ResearchTimeEfficiencyActivityID, 3, prices)
+	assert.NotNil(t, err)
+	value, err := EstimatedItemValue(blueprint, eveonline.ResearchTimeEfficiencyActivityID, 1, prices)
+	assert.Nil(t, err)
+	assert.InDelta(t, 100.0, value, 1e-6)
+}
@@ -0,0 +1,151 @@
+package industry
+
+import (
+	"math"
+
+	"github.com/pequalsnp/go-eveonline/pkg/esi"
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+)
+
+type SecurityBand int
+
+const (
+	HighSec SecurityBand = iota
+	LowSec
+	NullSec
+)
+
+// SecurityBandForStatus classifies a system the same way the client does, using the security
+// status rounded to one decimal place.  Any positive security below 0.05 is shown as 0.1, so those
+// systems are LowSec.  Wormhole space counts as NullSec.
+func SecurityBandForStatus(securityStatus float64) SecurityBand {
+	rounded := math.Round(securityStatus*10) / 10
+	if rounded >= 0.5 {
+		return HighSec
+	} else if securityStatus > 0.0 {
+		return LowSec
+	}
+	return NullSec
+}
+
+type Structure struct {
+	Name          string
+	MaterialBonus float64
+	TimeBonus     float64
+	CostBonus     float64
+	Activities    []eveonline.IndustryActivityID
+}
+
+func (s Structure) appliesTo(activityID eveonline.IndustryActivityID) bool {
+	for _, structureActivityID := range s.Activities {
+		if structureActivityID == activityID {
+			return true
+		}
+	}
+	return false
+}
+
+var engineeringActivities = []eveonline.IndustryActivityID{
+	eveonline.ManufacturingActivityID,
+	eveonline.ResearchTimeEfficiencyActivityID,
+	eveonline.ResearchMaterialEfficiencyActivityID,
+	eveonline.CopyingActivityID,
+	eveonline.InventionActivityID,
+}
+
+var refineryActivities = []eveonline.IndustryActivityID{eveonline.ReactionActivityID}
+
+var (
+	NPCStation = Structure{Name: "NPC Station"}
+	Raitaru    = Structure{Name: "Raitaru", MaterialBonus: 0.01, TimeBonus: 0.15, CostBonus: 0.03, Activities: engineeringActivities}
+	Azbel      = Structure{Name: "Azbel", MaterialBonus: 0.01, TimeBonus: 0.20, CostBonus: 0.04, Activities: engineeringActivities}
+	Sotiyo     = Structure{Name: "Sotiyo", MaterialBonus: 0.01, TimeBonus: 0.30, CostBonus: 0.05, Activities: engineeringActivities}
+	Athanor    = Structure{Name: "Athanor", Activities: refineryActivities}
+	Tatara     = Structure{Name: "Tatara", TimeBonus: 0.25, Activities: refineryActivities}
+)
+
+// Rig bonuses are the base values printed on the rig, before the security band multiplier.
+type Rig struct {
+	Name          string
+	MaterialBonus float64
+	TimeBonus     float64
+	Activity      eveonline.IndustryActivityID
+}
+
+var (
+	T1ManufacturingMaterialRig = Rig{Name: "Standup M-Set Material Efficiency I", MaterialBonus: 0.02, Activity: eveonline.ManufacturingActivityID}
+	T2ManufacturingMaterialRig = Rig{Name: "Standup M-Set Material Efficiency II", MaterialBonus: 0.024, Activity: eveonline.ManufacturingActivityID}
+	T1ManufacturingTimeRig     = Rig{Name: "Standup M-Set Time Efficiency I", TimeBonus: 0.20, Activity: eveonline.ManufacturingActivityID}
+	T2ManufacturingTimeRig     = Rig{Name: "Standup M-Set Time Efficiency II", TimeBonus: 0.24, Activity: eveonline.ManufacturingActivityID}
+	// Reactor rigs only fit refineries and cut both materials and time.
+	T1ReactionRig = Rig{Name: "Standup L-Set Reactor Efficiency I", MaterialBonus: 0.02, TimeBonus: 0.20, Activity: eveonline.ReactionActivityID}
+	T2ReactionRig = Rig{Name: "Standup L-Set Reactor Efficiency II", MaterialBonus: 0.024, TimeBonus: 0.24, Activity: eveonline.ReactionActivityID}
+)
+
+func rigSecurityMultiplier(activityID eveonline.IndustryActivityID, band SecurityBand) float64 {
+	if activityID == eveonline.ReactionActivityID {
+		switch band {
+		case LowSec:
+			return 1.0
+		case NullSec:
+			return 1.1
+		}
+		return 0.0
+	}
+
+	switch band {
+	case LowSec:
+		return 1.9
+	case NullSec:
+		return 2.1
+	}
+	return 1.0
+}
+
+type Facility struct {
+	Structure Structure
+	Rigs      []Rig
+	Security  SecurityBand
+}
+
+func (f Facility) MaterialModifier(activityID eveonline.IndustryActivityID) float64 {
+	modifier := 1.0
+	if f.Structure.appliesTo(activityID) {
+		modifier *= 1 - f.Structure.MaterialBonus
+	}
+	for _, rig := range f.Rigs {
+		if rig.Activity == activityID {
+			modifier *= 1 - rig.MaterialBonus*rigSecurityMultiplier(activityID, f.Security)
+		}
+	}
+	return modifier
+}
+
+func (f Facility) TimeModifier(activityID eveonline.IndustryActivityID) float64 {
+	modifier := 1.0
+	if f.Structure.appliesTo(activityID) {
+		modifier *= 1 - f.Structure.TimeBonus
+	}
+	for _, rig := range f.Rigs {
+		if rig.Activity == activityID {
+			modifier *= 1 - rig.TimeBonus*rigSecurityMultiplier(activityID, f.Security)
+		}
+	}
+	return modifier
+}
+
+const (
+	IndustrySkillID         = eveonline.SkillID(3380)
+	AdvancedIndustrySkillID = eveonline.SkillID(3388)
+	ReactionsSkillID        = eveonline.SkillID(45746)
+)
+
+type SkillLevels map[eveonline.SkillID]int
+
+func SkillLevelsFromESI(characterSkills *esi.CharacterSkills) SkillLevels {
+	levels := make(SkillLevels)
+	for skillID, skill := range characterSkills.Skills {
+		levels[skillID] = skill.ActiveLevel
+	}
+	return levels
+}
@@ -0,0 +1,112 @@
+package industry
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+	"github.com/pequalsnp/go-eveonline/pkg/sde"
+)
+
+type JobParameters struct {
+	Runs               int
+	MaterialEfficiency int
+	TimeEfficiency     int
+	Facility           Facility
+	Skills             SkillLevels
+	ImplantTimeBonus   float64
+}
+
+type JobEstimate struct {
+	ActivityID    eveonline.IndustryActivityID
+	Runs          int
+	Materials     []sde.TypeQuantity
+	Products      []sde.TypeQuantity
+	TimeInSeconds int
+}
+
+// MaterialQuantity applies the client's rounding rules: the adjusted quantity is rounded to two
+// decimal places before being rounded up, and a job never needs less than one unit per run.
+func MaterialQuantity(baseQuantity int, runs int, modifier float64) int {
+	adjusted := float64(runs) * float64(baseQuantity) * modifier
+	adjusted = math.Ceil(math.Round(adjusted*100) / 100)
+	return int(math.Max(float64(runs), adjusted))
+}
+
+func (p JobParameters) materialModifier(activityID eveonline.IndustryActivityID) float64 {
+	modifier := p.Facility.MaterialModifier(activityID)
+	if activityID == eveonline.ManufacturingActivityID {
+		modifier *= 1 - float64(p.MaterialEfficiency)/100
+	}
+	return modifier
+}
+
+func (p JobParameters) timeModifier(activityID eveonline.IndustryActivityID, activity *sde.Activity) float64 {
+	modifier := p.Facility.TimeModifier(activityID)
+	modifier *= 1 - p.ImplantTimeBonus
+
+	switch activityID {
+	case eveonline.ManufacturingActivityID:
+		modifier *= 1 - float64(p.TimeEfficiency)/100
+		modifier *= 1 - 0.04*float64(p.Skills[IndustrySkillID])
+		modifier *= 1 - 0.03*float64(p.Skills[AdvancedIndustrySkillID])
+		// Every other skill a blueprint requires (ship construction, science) is worth 1% per level.
+		for _, requiredSkill := range activity.RequiredSkills {
+			skillID := eveonline.SkillID(requiredSkill.SkillID)
+			if skillID == IndustrySkillID || skillID == AdvancedIndustrySkillID {
+				continue
+			}
+			modifier *= 1 - 0.01*float64(p.Skills[skillID])
+		}
+	case eveonline.ReactionActivityID:
+		modifier *= 1 - 0.04*float64(p.Skills[ReactionsSkillID])
+	default:
+		modifier *= 1 - 0.03*float64(p.Skills[AdvancedIndustrySkillID])
+	}
+
+	return modifier
+}
+
+func EstimateJob(
+	blueprint *sde.Blueprint,
+	activityID eveonline.IndustryActivityID,
+	parameters JobParameters,
+) (*JobEstimate, error) {
+	activity := blueprint.Activities.Activity(activityID)
+	if activity == nil {
+		return nil, fmt.Errorf("Blueprint %d does not support activity %d", blueprint.BlueprintTypeID, activityID)
+	}
+	if parameters.Runs < 1 {
+		return nil, fmt.Errorf("Invalid run count %d for blueprint %d", parameters.Runs, blueprint.BlueprintTypeID)
+	}
+
+	materialModifier := parameters.materialModifier(activityID)
+	materials := make([]sde.TypeQuantity, 0, len(activity.Materials))
+	for _, material := range activity.Materials {
+		materials = append(materials, sde.TypeQuantity{
+			TypeID:   material.TypeID,
+			Quantity: MaterialQuantity(material.Quantity, parameters.Runs, materialModifier),
+		})
+	}
+	sort.Slice(materials, func(i, j int) bool { return materials[i].TypeID < materials[j].TypeID })
+
+	products := make([]sde.TypeQuantity, 0, len(activity.Products))
+	for _, product := range activity.Products {
+		products = append(products, sde.TypeQuantity{
+			TypeID:      product.TypeID,
+			Quantity:    product.Quantity * parameters.Runs,
+			Probability: product.Probability,
+		})
+	}
+
+	seconds := float64(activity.TimeInSeconds*parameters.Runs) * parameters.timeModifier(activityID, activity)
+
+	return &JobEstimate{
+		ActivityID:    activityID,
+		Runs:          parameters.Runs,
+		Materials:     materials,
+		Products:      products,
+		TimeInSeconds: int(math.Round(seconds)),
+	}, nil
+}
@@ -0,0 +1,126 @@
+package industry
+
+import (
+	"testing"
+
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+	"github.com/pequalsnp/go-eveonline/pkg/sde"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaterialQuantity(t *testing.T) {
+	tests := []struct {
+		name         string
+		baseQuantity int
+		runs         int
+		me           int
+		facility     Facility
+		expected     int
+	}{
+		{"ME 10 single run rounds up", 86, 1, 10, Facility{Structure: NPCStation}, 78},
+		{"ME 10 ten runs", 86, 10, 10, Facility{Structure: NPCStation}, 774},
+		{"ME 0", 86, 1, 0, Facility{Structure: NPCStation}, 86},
+		{"single unit materials are not reduced", 1, 10, 10, Facility{Structure: Sotiyo, Rigs: []Rig{T2ManufacturingMaterialRig}, Security: NullSec}, 10},
+		{"raitaru with T1 rig in highsec", 100, 10, 10, Facility{Structure: Raitaru, Rigs: []Rig{T1ManufacturingMaterialRig}, Security: HighSec}, 874},
+		{"azbel with T2 rig in nullsec", 100, 10, 10, Facility{Structure: Azbel, Rigs: []Rig{T2ManufacturingMaterialRig}, Security: NullSec}, 847},
+		{"lowsec rig multiplier", 1000, 1, 10, Facility{Structure: Raitaru, Rigs: []Rig{T1ManufacturingMaterialRig}, Security: LowSec}, 858},
+		{"fractions below a hundredth are dropped", 67, 3, 1, Facility{Structure: Raitaru}, 197},
+		{"time rigs do not change materials", 100, 1, 10, Facility{Structure: NPCStation, Rigs: []Rig{T2ManufacturingTimeRig}}, 90},
+	}
+
+	for _, test := range tests {
+		parameters := JobParameters{Runs: test.runs, MaterialEfficiency: test.me, Facility: test.facility}
+		modifier := parameters.materialModifier(eveonline.ManufacturingActivityID)
+		assert.Equal(t, test.expected, MaterialQuantity(test.baseQuantity, test.runs, modifier), test.name)
+	}
+}
+
+func TestEstimateJob(t *testing.T) {
+	blueprint := &sde.Blueprint{
+		BlueprintTypeID: 691,
+		Activities: &sde.Activities{
+			Manufacturing: &sde.Manufacturing{
+				Materials: []sde.TypeQuantity{
+					{TypeID: 35, Quantity: 6000},
+					{TypeID: 34, Quantity: 32000},
+				},
+				Products:       []sde.TypeQuantity{{TypeID: 587, Quantity: 1}},
+				TimeInSeconds:  6000,
+				RequiredSkills: []sde.SkillLevel{{SkillID: 3380, Level: 1}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		parameters JobParameters
+		materials  []sde.TypeQuantity
+		seconds    int
+	}{
+		{
+			name:       "unresearched in a station without skills",
+			parameters: JobParameters{Runs: 1, Facility: Facility{Structure: NPCStation}},
+			materials:  []sde.TypeQuantity{{TypeID: 34, Quantity: 32000}, {TypeID: 35, Quantity: 6000}},
+			seconds:    6000,
+		},
+		{
+			name: "researched in a raitaru with max skills",
+			parameters: JobParameters{
+				Runs:               1,
+				MaterialEfficiency: 10,
+				TimeEfficiency:     20,
+				Facility:           Facility{Structure: Raitaru},
+				Skills:             SkillLevels{IndustrySkillID: 5, AdvancedIndustrySkillID: 5},
+			},
+			materials: []sde.TypeQuantity{{TypeID: 34, Quantity: 28512}, {TypeID: 35, Quantity: 5346}},
+			seconds:   2774,
+		},
+		{
+			// The Rifter as the client quotes it from a fully researched original in an NPC
+			// station with Industry and Advanced Industry at V: 28,800 Tritanium, 5,400 Pyerite
+			// and 54m 24s per run.
+			name: "in-game quote for a rifter in an npc station",
+			parameters: JobParameters{
+				Runs:               1,
+				MaterialEfficiency: 10,
+				TimeEfficiency:     20,
+				Facility:           Facility{Structure: NPCStation, Security: HighSec},
+				Skills:             SkillLevels{IndustrySkillID: 5, AdvancedIndustrySkillID: 5},
+			},
+			materials: []sde.TypeQuantity{{TypeID: 34, Quantity: 28800}, {TypeID: 35, Quantity: 5400}},
+			seconds:   3264,
+		},
+		{
+			name: "implant and time rig",
+			parameters: JobParameters{
+				Runs:             10,
+				Facility:         Facility{Structure: NPCStation, Rigs: []Rig{T1ManufacturingTimeRig}},
+				ImplantTimeBonus: 0.04,
+			},
+			materials: []sde.TypeQuantity{{TypeID: 34, Quantity: 320000}, {TypeID: 35, Quantity: 60000}},
+			seconds:   46080,
+		},
+	}
+
+	for _, test := range tests {
+		estimate, err := EstimateJob(blueprint, eveonline.ManufacturingActivityID, test.parameters)
+		assert.Nil(t, err, test.name)
+		assert.Equal(t, test.materials, estimate.Materials, test.name)
+		assert.Equal(t, test.seconds, estimate.TimeInSeconds, test.name)
+		assert.Equal(t, test.parameters.Runs, estimate.Products[0].Quantity, test.name)
+	}
+
+	_, err := EstimateJob(blueprint, eveonline.ReactionActivityID, JobParameters{Runs: 1})
+	assert.NotNil(t, err)
+}
+
+func TestSecurityBandForStatus(t *testing.T) {
+	assert.Equal(t, HighSec, SecurityBandForStatus(1.0))
+	assert.Equal(t, HighSec, SecurityBandForStatus(0.45))
+	assert.Equal(t, LowSec, SecurityBandForStatus(0.44))
+	assert.Equal(t, LowSec, SecurityBandForStatus(0.05))
+	assert.Equal(t, LowSec, SecurityBandForStatus(0.04))
+	assert.Equal(t, LowSec, SecurityBandForStatus(0.0001))
+	assert.Equal(t, NullSec, SecurityBandForStatus(0.0))
+	assert.Equal(t, NullSec, SecurityBandForStatus(-0.3))
+}
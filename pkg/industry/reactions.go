@@ -0,0 +1,206 @@
+package industry
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+	"github.com/pequalsnp/go-eveonline/pkg/sde"
+)
+
+var FuelBlockTypeIDs = map[eveonline.TypeID]bool{
+	4051: true, // Nitrogen Fuel Block
+	4246: true, // Hydrogen Fuel Block
+	4247: true, // Helium Fuel Block
+	4312: true, // Oxygen Fuel Block
+}
+
+type ReactionRuns struct {
+	Blueprint     *sde.Blueprint
+	Runs          int
+	SecondsPerRun int
+	// Level 0 reactions produce the targets, higher levels feed the level below them.
+	Level int
+}
+
+type ScheduledReaction struct {
+	BlueprintTypeID eveonline.TypeID
+	Runs            int
+	StartSeconds    int
+	EndSeconds      int
+}
+
+type ReactionPlan struct {
+	Reactions    []*ReactionRuns
+	Inputs       []sde.TypeQuantity
+	FuelBlocks   []sde.TypeQuantity
+	Excess       []sde.TypeQuantity
+	Schedule     [][]*ScheduledReaction
+	TotalSeconds int
+}
+
+// PlanReactions expands target reaction products into the intermediate reactions needed to
+// build them and schedules those reactions across slots, finishing each level of the chain
+// before the level that consumes it starts.
+func PlanReactions(
+	blueprints sde.ProductBlueprintMap,
+	targets []sde.TypeQuantity,
+	slots int,
+	parameters JobParameters,
+) (*ReactionPlan, error) {
+	if slots < 1 {
+		return nil, fmt.Errorf("Invalid reactor slot count %d", slots)
+	}
+
+	options := sde.BOMOptions{
+		Build: func(typeID eveonline.TypeID) bool {
+			for _, blueprint := range blueprints[typeID] {
+				if blueprint.IsReaction() {
+					return true
+				}
+			}
+			return false
+		},
+		Materials: func(blueprint *sde.Blueprint, runs int) ([]sde.TypeQuantity, error) {
+			jobParameters := parameters
+			jobParameters.Runs = runs
+			estimate, err := EstimateJob(blueprint, eveonline.ReactionActivityID, jobParameters)
+			if err != nil {
+				return nil, fmt.Errorf("Failed to estimate reaction %d, %v", blueprint.BlueprintTypeID, err)
+			}
+			return estimate.Materials, nil
+		},
+	}
+
+	bom, err := blueprints.ResolveBillOfMaterials(targets, options)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &ReactionPlan{Excess: bom.Excess}
+	for _, bought := range bom.ShoppingList {
+		if FuelBlockTypeIDs[bought.TypeID] {
+			plan.FuelBlocks = append(plan.FuelBlocks, bought)
+		} else {
+			plan.Inputs = append(plan.Inputs, bought)
+		}
+	}
+
+	levels := make(map[eveonline.TypeID]int)
+	byTypeID := make(map[eveonline.TypeID]*sde.Blueprint)
+	var walk func(node *sde.BOMNode, depth int)
+	walk = func(node *sde.BOMNode, depth int) {
+		if node.Blueprint == nil {
+			return
+		}
+		blueprintTypeID := node.Blueprint.BlueprintTypeID
+		byTypeID[blueprintTypeID] = node.Blueprint
+		if level, ok := levels[blueprintTypeID]; !ok || depth > level {
+			levels[blueprintTypeID] = depth
+		}
+		for _, child := range node.Children {
+			walk(child, depth+1)
+		}
+	}
+	for _, node := range bom.Tree {
+		walk(node, 0)
+	}
+
+	for _, runs := range bom.Runs {
+		blueprint := byTypeID[runs.TypeID]
+		oneRun := parameters
+		oneRun.Runs = 1
+		estimate, err := EstimateJob(blueprint, eveonline.ReactionActivityID, oneRun)
+		if err != nil {
+			return nil, err
+		}
+		plan.Reactions = append(plan.Reactions, &ReactionRuns{
+			Blueprint:     blueprint,
+			Runs:          runs.Quantity,
+			SecondsPerRun: estimate.TimeInSeconds,
+			Level:         levels[runs.TypeID],
+		})
+	}
+
+	plan.Schedule, plan.TotalSeconds = scheduleReactions(plan.Reactions, slots)
+
+	return plan, nil
+}
+
+func scheduleReactions(reactions []*ReactionRuns, slots int) ([][]*ScheduledReaction, int) {
+	schedule := make([][]*ScheduledReaction, slots)
+	slotFree := make([]int, slots)
+
+	maxLevel := 0
+	for _, reaction := range reactions {
+		if reaction.Level > maxLevel {
+			maxLevel = reaction.Level
+		}
+	}
+
+	levelStart := 0
+	for level := maxLevel; level >= 0; level-- {
+		chunks := make([]*ReactionRuns, 0)
+		for _, reaction := range reactions {
+			if reaction.Level == level {
+				chunk := *reaction
+				chunks = append(chunks, &chunk)
+			}
+		}
+		if len(chunks) == 0 {
+			continue
+		}
+
+		// Split the longest jobs until every slot has work or nothing can be split further.
+		for len(chunks) < slots {
+			sortChunksLongestFirst(chunks)
+			longest := chunks[0]
+			if longest.Runs < 2 {
+				break
+			}
+			split := *longest
+			split.Runs = longest.Runs / 2
+			longest.Runs -= split.Runs
+			chunks = append(chunks, &split)
+		}
+		sortChunksLongestFirst(chunks)
+
+		for i := range slotFree {
+			slotFree[i] = levelStart
+		}
+		levelEnd := levelStart
+		for _, chunk := range chunks {
+			slot := 0
+			for i := range slotFree {
+				if slotFree[i] < slotFree[slot] {
+					slot = i
+				}
+			}
+			end := slotFree[slot] + chunk.Runs*chunk.SecondsPerRun
+			schedule[slot] = append(schedule[slot], &ScheduledReaction{
+				BlueprintTypeID: chunk.Blueprint.BlueprintTypeID,
+				Runs:            chunk.Runs,
+				StartSeconds:    slotFree[slot],
+				EndSeconds:      end,
+			})
+			slotFree[slot] = end
+			if end > levelEnd {
+				levelEnd = end
+			}
+		}
+		levelStart = levelEnd
+	}
+
+	return schedule, levelStart
+}
+
+func sortChunksLongestFirst(chunks []*ReactionRuns) {
+	sort.SliceStable(chunks, func(i, j int) bool {
+		iSeconds := chunks[i].Runs * chunks[i].SecondsPerRun
+		jSeconds := chunks[j].Runs * chunks[j].SecondsPerRun
+		if iSeconds != jSeconds {
+			return iSeconds > jSeconds
+		}
+		return chunks[i].Blueprint.BlueprintTypeID < chunks[j].Blueprint.BlueprintTypeID
+	})
+}
@@ -0,0 +1,75 @@
+package industry
+
+import (
+	"testing"
+
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+	"github.com/pequalsnp/go-eveonline/pkg/sde"
+	"github.com/stretchr/testify/assert"
+)
+
+func reactionFormula(blueprintTypeID eveonline.TypeID, product sde.TypeQuantity, materials ...sde.TypeQuantity) *sde.Blueprint {
+	return &sde.Blueprint{
+		BlueprintTypeID: blueprintTypeID,
+		Activities: &sde.Activities{Reaction: &sde.Reaction{
+			Materials:     materials,
+			Products:      []sde.TypeQuantity{product},
+			TimeInSeconds: 10800,
+		}},
+	}
+}
+
+func TestPlanReactions(t *testing.T) {
+	fuel := eveonline.TypeID(4051)
+	simpleA := reactionFormula(100, sde.TypeQuantity{TypeID: 1001, Quantity: 200},
+		sde.TypeQuantity{TypeID: 1, Quantity: 100}, sde.TypeQuantity{TypeID: 2, Quantity: 100}, sde.TypeQuantity{TypeID: fuel, Quantity: 5})
+	simpleB := reactionFormula(101, sde.TypeQuantity{TypeID: 1002, Quantity: 200},
+		sde.TypeQuantity{TypeID: 3, Quantity: 100}, sde.TypeQuantity{TypeID: 4, Quantity: 100}, sde.TypeQuantity{TypeID: fuel, Quantity: 5})
+	composite := reactionFormula(200, sde.TypeQuantity{TypeID: 2001, Quantity: 200},
+		sde.TypeQuantity{TypeID: 1001, Quantity: 100}, sde.TypeQuantity{TypeID: 1002, Quantity: 100}, sde.TypeQuantity{TypeID: fuel, Quantity: 5})
+	blueprints := sde.ProductBlueprintMap{
+		1001: []*sde.Blueprint{simpleA},
+		1002: []*sde.Blueprint{simpleB},
+		2001: []*sde.Blueprint{composite},
+	}
+
+	plan, err := PlanReactions(blueprints, []sde.TypeQuantity{{TypeID: 2001, Quantity: 400}}, 2, JobParameters{Facility: Facility{Structure: Athanor}})
+	assert.Nil(t, err)
+
+	assert.Equal(t, []sde.TypeQuantity{
+		{TypeID: 1, Quantity: 100},
+		{TypeID: 2, Quantity: 100},
+		{TypeID: 3, Quantity: 100},
+		{TypeID: 4, Quantity: 100},
+	}, plan.Inputs)
+	assert.Equal(t, []sde.TypeQuantity{{TypeID: fuel, Quantity: 20}}, plan.FuelBlocks)
+	assert.Len(t, plan.Reactions, 3)
+	for _, reaction := range plan.Reactions {
+		assert.Equal(t, 10800, reaction.SecondsPerRun)
+		if reaction.Blueprint.BlueprintTypeID == 200 {
+			assert.Equal(t, 0, reaction.Level)
+			assert.Equal(t, 2, reaction.Runs)
+		} else {
+			assert.Equal(t, 1, reaction.Level)
+			assert.Equal(t, 1, reaction.Runs)
+		}
+	}
+
+	assert.Equal(t, 21600, plan.TotalSeconds)
+	assert.Equal(t, []*ScheduledReaction{
+		{BlueprintTypeID: 100, Runs: 1, StartSeconds: 0, EndSeconds: 10800},
+		{BlueprintTypeID: 200, Runs: 1, StartSeconds: 10800, EndSeconds: 21600},
+	}, plan.Schedule[0])
+	assert.Equal(t, []*ScheduledReaction{
+		{BlueprintTypeID: 101, Runs: 1, StartSeconds: 0, EndSeconds: 10800},
+		{BlueprintTypeID: 200, Runs: 1, StartSeconds: 10800, EndSeconds: 21600},
+	}, plan.Schedule[1])
+
+	plan, err = PlanReactions(blueprints, []sde.TypeQuantity{{TypeID: 2001, Quantity: 400}}, 1, JobParameters{Facility: Facility{Structure: Tatara}})
+	assert.Nil(t, err)
+	assert.Equal(t, 8100, plan.Reactions[0].SecondsPerRun)
+	assert.Equal(t, 4*8100, plan.TotalSeconds)
+
+	_, err = PlanReactions(blueprints, []sde.TypeQuantity{{TypeID: 2001, Quantity: 400}}, 0, JobParameters{})
+	assert.NotNil(t, err)
+}
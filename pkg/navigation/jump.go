@@ -0,0 +1,197 @@
+package navigation
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+	"github.com/pequalsnp/go-eveonline/pkg/sde"
+)
+
+// Meters per light year as used by the game client.
+const LightYear = 9.4605284e15
+
+const (
+	JumpDriveCalibrationSkillID = eveonline.TypeID(21611)
+	JumpFuelConservationSkillID = eveonline.TypeID(21610)
+)
+
+const (
+	jumpDriveCalibrationBonus = 0.20
+	jumpFuelConservationBonus = 0.10
+
+	minimumJumpFatigue = 10 * time.Minute
+	maximumJumpFatigue = 5 * time.Hour
+)
+
+func LightYearsBetween(from *sde.SolarSystem, to *sde.SolarSystem) float64 {
+	return from.Center.DistanceTo(to.Center) / LightYear
+}
+
+type JumpSkills struct {
+	JumpDriveCalibration int
+	JumpFuelConservation int
+}
+
+type JumpDrive struct {
+	Range             float64
+	FuelTypeID        eveonline.TypeID
+	FuelPerLightYear  float64
+	FatigueMultiplier float64
+}
+
+// JumpDriveForShip reads the jump drive attributes of a ship type and applies the range and fuel
+// skills.  Ships without a jump drive return an error.
+func JumpDriveForShip(dogma *sde.DogmaStore, shipTypeID eveonline.TypeID, skills JumpSkills) (*JumpDrive, error) {
+	baseRange, ok := dogma.TypeAttribute(shipTypeID, sde.JumpDriveRangeAttributeID)
+	if !ok || baseRange <= 0 {
+		return nil, fmt.Errorf("Ship type %d has no jump drive", shipTypeID)
+	}
+	fuelTypeID, _ := dogma.TypeAttribute(shipTypeID, sde.JumpDriveConsumptionTypeAttributeID)
+	fuelPerLightYear, _ := dogma.TypeAttribute(shipTypeID, sde.JumpDriveConsumptionAmountAttributeID)
+	fatigueMultiplier, ok := dogma.TypeAttribute(shipTypeID, sde.JumpFatigueMultiplierAttributeID)
+	if !ok {
+		fatigueMultiplier = 1.0
+	}
+
+	return &JumpDrive{
+		Range:             baseRange * (1.0 + jumpDriveCalibrationBonus*float64(skills.JumpDriveCalibration)),
+		FuelTypeID:        eveonline.TypeID(fuelTypeID),
+		FuelPerLightYear:  fuelPerLightYear * (1.0 - jumpFuelConservationBonus*float64(skills.JumpFuelConservation)),
+		FatigueMultiplier: fatigueMultiplier,
+	}, nil
+}
+
+func (d *JumpDrive) Fuel(lightYears float64) int {
+	return int(math.Ceil(lightYears * d.FuelPerLightYear))
+}
+
+// Jump drives cannot target high security or wormhole space.  The SDE wormhole class is also set
+// for known space, so wormhole systems are told apart by their id range.
+func canJumpInto(system *sde.SolarSystem) bool {
+	return !isHighSec(system.Security) && system.ID < 31000000
+}
+
+type JumpTarget struct {
+	SystemID   eveonline.SystemID
+	LightYears float64
+}
+
+// SystemsInJumpRange lists the systems a jump drive can reach from origin, nearest first.
+func SystemsInJumpRange(universe *sde.Universe, origin eveonline.SystemID, jumpRange float64) ([]JumpTarget, error) {
+	from, ok := universe.Systems[origin]
+	if !ok {
+		return nil, fmt.Errorf("Unknown system %d", origin)
+	}
+
+	targets := make([]JumpTarget, 0)
+	for systemID, system := range universe.Systems {
+		if systemID == origin || !canJumpInto(system) {
+			continue
+		}
+		lightYears := LightYearsBetween(from, system)
+		if lightYears <= jumpRange {
+			targets = append(targets, JumpTarget{SystemID: systemID, LightYears: lightYears})
+		}
+	}
+	sort.Slice(targets, func(i, j int) bool {
+		if targets[i].LightYears != targets[j].LightYears {
+			return targets[i].LightYears < targets[j].LightYears
+		}
+		return targets[i].SystemID < targets[j].SystemID
+	})
+	return targets, nil
+}
+
+type JumpFatigue struct {
+	Fatigue      time.Duration
+	Reactivation time.Duration
+}
+
+// NextJumpFatigue applies one jump of the given distance to the fatigue a pilot currently has.
+// Fatigue is capped at five hours, which also caps the reactivation timer at thirty minutes.
+func NextJumpFatigue(current time.Duration, lightYears float64, fatigueMultiplier float64) JumpFatigue {
+	effectiveLightYears := lightYears * fatigueMultiplier
+
+	fatigue := current
+	if fatigue < minimumJumpFatigue {
+		fatigue = minimumJumpFatigue
+	}
+	fatigue = time.Duration(float64(fatigue) * (1.0 + effectiveLightYears))
+	if fatigue > maximumJumpFatigue {
+		fatigue = maximumJumpFatigue
+	}
+
+	reactivation := time.Duration((1.0 + effectiveLightYears) * float64(time.Minute))
+	if fatigue/10 > reactivation {
+		reactivation = fatigue / 10
+	}
+
+	return JumpFatigue{Fatigue: fatigue, Reactivation: reactivation}
+}
+
+type JumpLeg struct {
+	From       eveonline.SystemID
+	To         eveonline.SystemID
+	LightYears float64
+	Fuel       int
+	// Wait is how long the pilot sits on the previous reactivation timer before this jump.
+	Wait time.Duration
+	JumpFatigue
+}
+
+type JumpPlan struct {
+	Legs      []JumpLeg
+	Fuel      int
+	TotalWait time.Duration
+	// Fatigue is what the pilot is left with right after the last jump.
+	Fatigue time.Duration
+}
+
+// PlanJumps checks a sequence of systems against the jump drive and accumulates fuel and
+// fatigue, assuming each jump is taken as soon as the reactivation timer allows.  initialFatigue
+// is the fatigue the pilot has before the first jump.
+func PlanJumps(universe *sde.Universe, systemIDs []eveonline.SystemID, drive *JumpDrive, initialFatigue time.Duration) (*JumpPlan, error) {
+	plan := &JumpPlan{Legs: make([]JumpLeg, 0), Fatigue: initialFatigue}
+
+	var reactivation time.Duration
+	for i := 1; i < len(systemIDs); i++ {
+		from, ok := universe.Systems[systemIDs[i-1]]
+		if !ok {
+			return nil, fmt.Errorf("Unknown system %d", systemIDs[i-1])
+		}
+		to, ok := universe.Systems[systemIDs[i]]
+		if !ok {
+			return nil, fmt.Errorf("Unknown system %d", systemIDs[i])
+		}
+		if !canJumpInto(to) {
+			return nil, fmt.Errorf("Cannot jump into system %d", to.ID)
+		}
+		lightYears := LightYearsBetween(from, to)
+		if lightYears > drive.Range {
+			return nil, fmt.Errorf("System %d is %.2f light years from system %d, beyond the %.2f light year range", to.ID, lightYears, from.ID, drive.Range)
+		}
+
+		fatigue := plan.Fatigue - reactivation
+		if fatigue < 0 {
+			fatigue = 0
+		}
+		leg := JumpLeg{
+			From:        from.ID,
+			To:          to.ID,
+			LightYears:  lightYears,
+			Fuel:        drive.Fuel(lightYears),
+			Wait:        reactivation,
+			JumpFatigue: NextJumpFatigue(fatigue, lightYears, drive.FatigueMultiplier),
+		}
+		plan.Legs = append(plan.Legs, leg)
+		plan.Fuel += leg.Fuel
+		plan.TotalWait += leg.Wait
+		plan.Fatigue = leg.Fatigue
+		reactivation = leg.Reactivation
+	}
+
+	return plan, nil
+}
@@ -0,0 +1,103 @@
+package navigation
+
+import (
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+	"github.com/pequalsnp/go-eveonline/pkg/sde"
+	"github.com/stretchr/testify/assert"
+)
+
+func loadTestDogmaStore(t *testing.T) *sde.DogmaStore {
+	readFile := func(name string) []byte {
+		contents, err := ioutil.ReadFile("../../test/testdata/" + name)
+		if err != nil {
+			t.Fatalf("Failed to read %s test data: %v", name, err)
+		}
+		return contents
+	}
+
+	attributes, err := sde.ImportDogmaAttributes(readFile("dogmaAttributes.yaml"))
+	assert.Nil(t, err)
+	effects, err := sde.ImportDogmaEffects(readFile("dogmaEffects.yaml"))
+	assert.Nil(t, err)
+	typeDogma, err := sde.ImportTypeDogma(readFile("typeDogma.yaml"))
+	assert.Nil(t, err)
+
+	return sde.NewDogmaStore(attributes, effects, typeDogma)
+}
+
+func TestJumpDriveForShip(t *testing.T) {
+	dogma := loadTestDogmaStore(t)
+
+	archon, err := JumpDriveForShip(dogma, 23757, JumpSkills{JumpDriveCalibration: 5, JumpFuelConservation: 4})
+	assert.Nil(t, err)
+	assert.InDelta(t, 7.0, archon.Range, 0.0001)
+	assert.Equal(t, eveonline.TypeID(16272), archon.FuelTypeID)
+	assert.InDelta(t, 1800.0, archon.FuelPerLightYear, 0.0001)
+	assert.Equal(t, 1.0, archon.FatigueMultiplier)
+	assert.Equal(t, 4500, archon.Fuel(2.5))
+
+	anshar, err := JumpDriveForShip(dogma, 28848, JumpSkills{})
+	assert.Nil(t, err)
+	assert.Equal(t, 5.0, anshar.Range)
+	assert.Equal(t, 0.1, anshar.FatigueMultiplier)
+
+	_, err = JumpDriveForShip(dogma, 587, JumpSkills{JumpDriveCalibration: 5})
+	assert.NotNil(t, err)
+}
+
+func TestNextJumpFatigue(t *testing.T) {
+	first := NextJumpFatigue(0, 4.0, 1.0)
+	assert.Equal(t, 50*time.Minute, first.Fatigue)
+	assert.Equal(t, 5*time.Minute, first.Reactivation)
+
+	second := NextJumpFatigue(first.Fatigue, 4.0, 1.0)
+	assert.Equal(t, 250*time.Minute, second.Fatigue)
+	assert.Equal(t, 25*time.Minute, second.Reactivation)
+
+	capped := NextJumpFatigue(second.Fatigue, 4.0, 1.0)
+	assert.Equal(t, 5*time.Hour, capped.Fatigue)
+	assert.Equal(t, 30*time.Minute, capped.Reactivation)
+
+	reduced := NextJumpFatigue(0, 5.0, 0.1)
+	assert.Equal(t, 15*time.Minute, reduced.Fatigue)
+	assert.Equal(t, 90*time.Second, reduced.Reactivation)
+}
+
+func TestJumpRangeAndPlan(t *testing.T) {
+	universe := loadTestUniverse(t)
+	universe.Systems[perimeter].Security = 0.3
+	universe.Systems[newCaldari].Security = -0.2
+
+	distance := LightYearsBetween(universe.Systems[jita], universe.Systems[perimeter])
+	assert.True(t, distance > 0)
+
+	targets, err := SystemsInJumpRange(universe, perimeter, 100.0)
+	assert.Nil(t, err)
+	assert.Len(t, targets, 1)
+	assert.Equal(t, newCaldari, targets[0].SystemID)
+
+	targets, err = SystemsInJumpRange(universe, jita, distance/2)
+	assert.Nil(t, err)
+	assert.Empty(t, targets)
+
+	drive := &JumpDrive{Range: 100.0, FuelPerLightYear: 1000.0, FatigueMultiplier: 1.0}
+	plan, err := PlanJumps(universe, []eveonline.SystemID{jita, perimeter, newCaldari}, drive, 0)
+	assert.Nil(t, err)
+	assert.Len(t, plan.Legs, 2)
+	assert.Equal(t, drive.Fuel(distance), plan.Legs[0].Fuel)
+	assert.Equal(t, plan.Legs[0].Fuel+plan.Legs[1].Fuel, plan.Fuel)
+	assert.Equal(t, time.Duration(0), plan.Legs[0].Wait)
+	assert.Equal(t, plan.Legs[0].Reactivation, plan.Legs[1].Wait)
+	assert.Equal(t, plan.Legs[1].Fatigue, plan.Fatigue)
+
+	_, err = PlanJumps(universe, []eveonline.SystemID{perimeter, jita}, drive, 0)
+	assert.NotNil(t, err)
+
+	drive.Range = distance / 2
+	_, err = PlanJumps(universe, []eveonline.SystemID{jita, perimeter}, drive, 0)
+	assert.NotNil(t, err)
+}
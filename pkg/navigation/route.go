@@ -0,0 +1,233 @@
+package navigation
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+	"github.com/pequalsnp/go-eveonline/pkg/sde"
+)
+
+type RouteFlag string
+
+const (
+	Shortest RouteFlag = "shortest"
+	Secure   RouteFlag = "secure"
+	Insecure RouteFlag = "insecure"
+)
+
+// Jumps into systems the flag would rather avoid cost this much, so any route staying in the
+// preferred space wins unless it is absurdly long.
+const avoidedSpacePenalty = 50000.0
+
+type RouteOptions struct {
+	Flag  RouteFlag
+	Avoid []eveonline.SystemID
+	// Weight overrides the flag when set.  It returns the cost of jumping from one system into
+	// the next; a cost below zero makes the jump impassable.
+	Weight func(from *sde.SolarSystem, to *sde.SolarSystem) float64
+}
+
+type NoRouteError struct {
+	Origin      eveonline.SystemID
+	Destination eveonline.SystemID
+}
+
+func (e NoRouteError) Error() string {
+	return fmt.Sprintf("No route from system %d to system %d", e.Origin, e.Destination)
+}
+
+type Router struct {
+	Universe *sde.Universe
+}
+
+func NewRouter(universe *sde.Universe) *Router {
+	return &Router{Universe: universe}
+}
+
+func isHighSec(security float64) bool {
+	return math.Round(security*10)/10 >= 0.5
+}
+
+func (options RouteOptions) weight() func(from *sde.SolarSystem, to *sde.SolarSystem) float64 {
+	if options.Weight != nil {
+		return options.Weight
+	}
+	switch options.Flag {
+	case Secure:
+		return func(from *sde.SolarSystem, to *sde.SolarSystem) float64 {
+			if isHighSec(to.Security) {
+				return 1.0
+			}
+			return avoidedSpacePenalty
+		}
+	case Insecure:
+		return func(from *sde.SolarSystem, to *sde.SolarSystem) float64 {
+			if isHighSec(to.Security) {
+				return avoidedSpacePenalty
+			}
+			return 1.0
+		}
+	}
+	return func(from *sde.SolarSystem, to *sde.SolarSystem) float64 {
+		return 1.0
+	}
+}
+
+type routeEntry struct {
+	systemID eveonline.SystemID
+	cost     float64
+}
+
+type routeQueue []routeEntry
+
+func (q routeQueue) Len() int { return len(q) }
+func (q routeQueue) Less(i, j int) bool {
+	if q[i].cost != q[j].cost {
+		return q[i].cost < q[j].cost
+	}
+	return q[i].systemID < q[j].systemID
+}
+func (q routeQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *routeQueue) Push(x interface{}) { *q = append(*q, x.(routeEntry)) }
+func (q *routeQueue) Pop() interface{} {
+	old := *q
+	entry := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return entry
+}
+
+// shortestPaths runs Dijkstra from origin, stopping early once destination is settled when it is
+// non-zero.  It returns the cost to and predecessor of every settled system.
+func (r *Router) shortestPaths(origin eveonline.SystemID, destination eveonline.SystemID, options RouteOptions) (map[eveonline.SystemID]float64, map[eveonline.SystemID]eveonline.SystemID) {
+	avoid := make(map[eveonline.SystemID]bool)
+	for _, systemID := range options.Avoid {
+		avoid[systemID] = true
+	}
+	weight := options.weight()
+
+	costs := make(map[eveonline.SystemID]float64)
+	previous := make(map[eveonline.SystemID]eveonline.SystemID)
+	settled := make(map[eveonline.SystemID]bool)
+
+	queue := &routeQueue{{systemID: origin}}
+	costs[origin] = 0
+	for queue.Len() > 0 {
+		entry := heap.Pop(queue).(routeEntry)
+		if settled[entry.systemID] {
+			continue
+		}
+		settled[entry.systemID] = true
+		if entry.systemID == destination {
+			break
+		}
+
+		from := r.Universe.Systems[entry.systemID]
+		for _, neighbourID := range r.Universe.Neighbours(entry.systemID) {
+			if avoid[neighbourID] || settled[neighbourID] {
+				continue
+			}
+			to, ok := r.Universe.Systems[neighbourID]
+			if !ok {
+				continue
+			}
+			jumpCost := weight(from, to)
+			if jumpCost < 0 {
+				continue
+			}
+			cost := entry.cost + jumpCost
+			if known, ok := costs[neighbourID]; ok && known <= cost {
+				continue
+			}
+			costs[neighbourID] = cost
+			previous[neighbourID] = entry.systemID
+			heap.Push(queue, routeEntry{systemID: neighbourID, cost: cost})
+		}
+	}
+
+	for systemID := range costs {
+		if !settled[systemID] {
+			delete(costs, systemID)
+		}
+	}
+	return costs, previous
+}
+
+// Route returns the systems along the route, including origin and destination, in the same shape
+// as the ESI /route/ endpoint.
+func (r *Router) Route(origin eveonline.SystemID, destination eveonline.SystemID, options RouteOptions) ([]eveonline.SystemID, error) {
+	if _, ok := r.Universe.Systems[origin]; !ok {
+		return nil, fmt.Errorf("Unknown origin system %d", origin)
+	}
+	if _, ok := r.Universe.Systems[destination]; !ok {
+		return nil, fmt.Errorf("Unknown destination system %d", destination)
+	}
+
+	costs, previous := r.shortestPaths(origin, destination, options)
+	if _, ok := costs[destination]; !ok {
+		return nil, NoRouteError{Origin: origin, Destination: destination}
+	}
+
+	route := []eveonline.SystemID{destination}
+	for systemID := destination; systemID != origin; {
+		systemID = previous[systemID]
+		route = append(route, systemID)
+	}
+	for i, j := 0, len(route)-1; i < j; i, j = i+1, j-1 {
+		route[i], route[j] = route[j], route[i]
+	}
+	return route, nil
+}
+
+// RouteWaypoints plans a trip through each waypoint in order.  Waypoints are not reordered.
+func (r *Router) RouteWaypoints(waypoints []eveonline.SystemID, options RouteOptions) ([]eveonline.SystemID, error) {
+	if len(waypoints) == 0 {
+		return nil, fmt.Errorf("No waypoints given")
+	}
+
+	trip := []eveonline.SystemID{waypoints[0]}
+	for i := 1; i < len(waypoints); i++ {
+		leg, err := r.Route(waypoints[i-1], waypoints[i], options)
+		if err != nil {
+			return nil, err
+		}
+		trip = append(trip, leg[1:]...)
+	}
+	return trip, nil
+}
+
+// JumpMatrix counts the jumps along the preferred route between every pair of systems.  Pairs
+// without a route are left out.
+func (r *Router) JumpMatrix(systemIDs []eveonline.SystemID, options RouteOptions) (map[eveonline.SystemID]map[eveonline.SystemID]int, error) {
+	matrix := make(map[eveonline.SystemID]map[eveonline.SystemID]int)
+	for _, origin := range systemIDs {
+		if _, ok := r.Universe.Systems[origin]; !ok {
+			return nil, fmt.Errorf("Unknown system %d", origin)
+		}
+
+		costs, previous := r.shortestPaths(origin, 0, options)
+		matrix[origin] = make(map[eveonline.SystemID]int)
+		for _, destination := range systemIDs {
+			if _, ok := costs[destination]; !ok {
+				continue
+			}
+			jumps := 0
+			for systemID := destination; systemID != origin; systemID = previous[systemID] {
+				jumps++
+			}
+			matrix[origin][destination] = jumps
+		}
+	}
+	return matrix, nil
+}
+
+// Jumps counts the jumps along the shortest route between two systems, or returns false when
+// there is none.  It fits esi.BuyOrderReaches and the functions built on it.
+func (r *Router) Jumps(from eveonline.SystemID, to eveonline.SystemID) (int, bool) {
+	route, err := r.Route(from, to, RouteOptions{Flag: Shortest})
+	if err != nil {
+		return 0, false
+	}
+	return len(route) - 1, true
+}
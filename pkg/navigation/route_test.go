@@ -0,0 +1,98 @@
+package navigation
+
+import (
+	"testing"
+
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+	"github.com/pequalsnp/go-eveonline/pkg/sde"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	jita       eveonline.SystemID = 30000142
+	perimeter  eveonline.SystemID = 30000144
+	newCaldari eveonline.SystemID = 30000145
+)
+
+func loadTestUniverse(t *testing.T) *sde.Universe {
+	universe, err := sde.LoadUniverse("../../test/testdata/universe", map[int64]string{})
+	if err != nil {
+		t.Fatalf("Failed to load universe test data: %v", err)
+	}
+	return universe
+}
+
+func TestRoute(t *testing.T) {
+	universe := loadTestUniverse(t)
+	router := NewRouter(universe)
+
+	route, err := router.Route(jita, newCaldari, RouteOptions{Flag: Shortest})
+	assert.Nil(t, err)
+	assert.Equal(t, []eveonline.SystemID{jita, newCaldari}, route)
+
+	route, err = router.Route(jita, jita, RouteOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, []eveonline.SystemID{jita}, route)
+
+	route, err = router.Route(perimeter, newCaldari, RouteOptions{Avoid: []eveonline.SystemID{jita}})
+	assert.Nil(t, err)
+	assert.Equal(t, []eveonline.SystemID{perimeter, newCaldari}, route)
+
+	_, err = router.Route(jita, newCaldari, RouteOptions{Avoid: []eveonline.SystemID{perimeter, newCaldari}})
+	assert.Equal(t, NoRouteError{Origin: jita, Destination: newCaldari}, err)
+
+	route, err = router.Route(jita, newCaldari, RouteOptions{Weight: func(from *sde.SolarSystem, to *sde.SolarSystem) float64 {
+		if from.ID == jita && to.ID == newCaldari {
+			return -1
+		}
+		return 1
+	}})
+	assert.Nil(t, err)
+	assert.Equal(t, []eveonline.SystemID{jita, perimeter, newCaldari}, route)
+}
+
+func TestRouteSecurity(t *testing.T) {
+	universe := loadTestUniverse(t)
+	universe.Systems[newCaldari].Security = 0.44
+	router := NewRouter(universe)
+
+	route, err := router.Route(jita, newCaldari, RouteOptions{Flag: Secure})
+	assert.Nil(t, err)
+	assert.Equal(t, []eveonline.SystemID{jita, newCaldari}, route)
+
+	route, err = router.Route(jita, perimeter, RouteOptions{Flag: Insecure})
+	assert.Nil(t, err)
+	assert.Equal(t, []eveonline.SystemID{jita, perimeter}, route)
+
+	secure := RouteOptions{Flag: Secure}.weight()
+	insecure := RouteOptions{Flag: Insecure}.weight()
+	assert.Equal(t, 1.0, secure(universe.Systems[jita], universe.Systems[perimeter]))
+	assert.Equal(t, avoidedSpacePenalty, secure(universe.Systems[jita], universe.Systems[newCaldari]))
+	assert.Equal(t, avoidedSpacePenalty, insecure(universe.Systems[jita], universe.Systems[perimeter]))
+	assert.Equal(t, 1.0, insecure(universe.Systems[jita], universe.Systems[newCaldari]))
+}
+
+func TestRouteWaypointsAndJumpMatrix(t *testing.T) {
+	router := NewRouter(loadTestUniverse(t))
+
+	trip, err := router.RouteWaypoints([]eveonline.SystemID{perimeter, newCaldari, jita}, RouteOptions{Avoid: []eveonline.SystemID{}})
+	assert.Nil(t, err)
+	assert.Equal(t, []eveonline.SystemID{perimeter, newCaldari, jita}, trip)
+
+	matrix, err := router.JumpMatrix([]eveonline.SystemID{jita, perimeter}, RouteOptions{Avoid: []eveonline.SystemID{newCaldari}})
+	assert.Nil(t, err)
+	assert.Equal(t, map[eveonline.SystemID]map[eveonline.SystemID]int{
+		jita:      {jita: 0, perimeter: 1},
+		perimeter: {jita: 1, perimeter: 0},
+	}, matrix)
+
+	matrix, err = router.JumpMatrix([]eveonline.SystemID{jita, newCaldari}, RouteOptions{Avoid: []eveonline.SystemID{jita}})
+	assert.Nil(t, err)
+	assert.Equal(t, map[eveonline.SystemID]int{newCaldari: 0}, matrix[newCaldari])
+
+	jumps, ok := router.Jumps(perimeter, jita)
+	assert.True(t, ok)
+	assert.Equal(t, 1, jumps)
+	_, ok = router.Jumps(perimeter, 30002187)
+	assert.False(t, ok)
+}
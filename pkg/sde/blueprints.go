@@ -2,6 +2,7 @@ package sde
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
 	yaml "gopkg.in/yaml.v2"
@@ -10,8 +11,9 @@ import (
 type ProductBlueprintMap map[eveonline.TypeID][]*Blueprint
 
 type TypeQuantity struct {
-	TypeID   eveonline.TypeID `yaml:"typeID"`
-	Quantity int              `yaml:"quantity"`
+	TypeID      eveonline.TypeID `yaml:"typeID"`
+	Quantity    int              `yaml:"quantity"`
+	Probability float64          `yaml:"probability,omitempty"`
 }
 
 type SkillLevel struct {
@@ -23,38 +25,68 @@ func (tq TypeQuantity) String() string {
 	return fmt.Sprintf("type %d quantity %d", tq.TypeID, tq.Quantity)
 }
 
-type Reaction struct {
-	Materials []TypeQuantity `yaml:"materials"`
-	Products  []TypeQuantity `yaml:"products"`
-}
-
-type Manufacturing struct {
+type Activity struct {
 	Materials      []TypeQuantity `yaml:"materials"`
 	Products       []TypeQuantity `yaml:"products"`
 	TimeInSeconds  int            `yaml:"time"`
 	RequiredSkills []SkillLevel   `yaml:"skills"`
 }
 
+type Reaction Activity
+type Manufacturing Activity
+type Copying Activity
+type Invention Activity
+type ResearchMaterial Activity
+type ResearchTime Activity
+
 type Activities struct {
-	Reaction      *Reaction      `yaml:"reaction"`
-	Manufacturing *Manufacturing `yaml:"manufacturing"`
+	Reaction         *Reaction         `yaml:"reaction"`
+	Manufacturing    *Manufacturing    `yaml:"manufacturing"`
+	Copying          *Copying          `yaml:"copying"`
+	Invention        *Invention        `yaml:"invention"`
+	ResearchMaterial *ResearchMaterial `yaml:"research_material"`
+	ResearchTime     *ResearchTime     `yaml:"research_time"`
 }
 
 func (a Activities) String() string {
 	return fmt.Sprintf("%+v", a.Reaction)
 }
 
+// Activity returns the blueprint activity with the given ESI activity id, or nil if the
+// blueprint does not support it.
+func (a Activities) Activity(activityID eveonline.IndustryActivityID) *Activity {
+	switch activityID {
+	case eveonline.ManufacturingActivityID:
+		return (*Activity)(a.Manufacturing)
+	case eveonline.ReactionActivityID:
+		return (*Activity)(a.Reaction)
+	case eveonline.CopyingActivityID:
+		return (*Activity)(a.Copying)
+	case eveonline.InventionActivityID:
+		return (*Activity)(a.Invention)
+	case eveonline.ResearchMaterialEfficiencyActivityID:
+		return (*Activity)(a.ResearchMaterial)
+	case eveonline.ResearchTimeEfficiencyActivityID:
+		return (*Activity)(a.ResearchTime)
+	}
+
+	return nil
+}
+
 type Blueprint struct {
-	BlueprintTypeID eveonline.TypeID `yaml:"blueprintTypeID"`
-	Activities      *Activities      `yaml:"activities"`
+	BlueprintTypeID    eveonline.TypeID `yaml:"blueprintTypeID"`
+	MaxProductionLimit int              `yaml:"maxProductionLimit"`
+	Activities         *Activities      `yaml:"activities"`
 }
 
 func (b Blueprint) ProductsAndInputs() ([]TypeQuantity, []TypeQuantity) {
 	if b.IsReaction() {
 		return b.Activities.Reaction.Products, b.Activities.Reaction.Materials
-	} else {
+	} else if b.Activities.Manufacturing != nil {
 		return b.Activities.Manufacturing.Products, b.Activities.Manufacturing.Materials
 	}
+
+	return nil, nil
 }
 
 func (b *Blueprint) CreatesProducts() ([]eveonline.TypeID, error) {
@@ -79,13 +111,67 @@ func (b *Blueprint) IsReaction() bool {
 }
 
 func (b Blueprint) CanBeBuilt() bool {
-	return b.Activities.Manufacturing != nil || b.Activities.Manufacturing != nil
+	return b.Activities.Manufacturing != nil || b.Activities.Reaction != nil
 }
 
-func ImportBlueprints(blueprintsFileContents []byte) (ProductBlueprintMap, error) {
-	m := make(map[interface{}]interface{})
-	yaml.Unmarshal(blueprintsFileContents, &m)
+// CanInvent reports whether this blueprint is the source of an invention job, e.g. a T1
+// blueprint; use InventionSources to find what a blueprint is invented from.
+func (b Blueprint) CanInvent() bool {
+	return b.Activities.Invention != nil
+}
+
+func (m ProductBlueprintMap) BlueprintsByTypeID() map[eveonline.TypeID]*Blueprint {
+	byTypeID := make(map[eveonline.TypeID]*Blueprint)
+	for _, blueprints := range m {
+		for _, blueprint := range blueprints {
+			byTypeID[blueprint.BlueprintTypeID] = blueprint
+		}
+	}
+
+	return byTypeID
+}
 
+// InventionSources maps each invented blueprint type to the blueprints that invent into it,
+// e.g. a T2 blueprint copy to its T1 blueprint.
+func (m ProductBlueprintMap) InventionSources() map[eveonline.TypeID][]*Blueprint {
+	sources := make(map[eveonline.TypeID][]*Blueprint)
+	for _, blueprint := range m.BlueprintsByTypeID() {
+		if !blueprint.CanInvent() {
+			continue
+		}
+		for _, product := range blueprint.Activities.Invention.Products {
+			sources[product.TypeID] = append(sources[product.TypeID], blueprint)
+		}
+	}
+	sortBlueprintLists(sources)
+
+	return sources
+}
+
+// MaterialConsumers maps each material type to the blueprints that consume it when
+// manufacturing or reacting.
+func (m ProductBlueprintMap) MaterialConsumers() map[eveonline.TypeID][]*Blueprint {
+	consumers := make(map[eveonline.TypeID][]*Blueprint)
+	for _, blueprint := range m.BlueprintsByTypeID() {
+		_, materials := blueprint.ProductsAndInputs()
+		for _, material := range materials {
+			consumers[material.TypeID] = append(consumers[material.TypeID], blueprint)
+		}
+	}
+	sortBlueprintLists(consumers)
+
+	return consumers
+}
+
+func sortBlueprintLists(index map[eveonline.TypeID][]*Blueprint) {
+	for _, blueprints := range index {
+		sort.Slice(blueprints, func(i, j int) bool {
+			return blueprints[i].BlueprintTypeID < blueprints[j].BlueprintTypeID
+		})
+	}
+}
+
+func ImportBlueprints(blueprintsFileContents []byte) (ProductBlueprintMap, error) {
 	blueprintMap := make(map[eveonline.TypeID]Blueprint)
 	err := yaml.Unmarshal(blueprintsFileContents, &blueprintMap)
 	if err != nil {
@@ -94,19 +180,17 @@ func ImportBlueprints(blueprintsFileContents []byte) (ProductBlueprintMap, error
 
 	blueprints := make(ProductBlueprintMap)
 	for _, blueprint := range blueprintMap {
+		if blueprint.Activities == nil {
+			continue
+		}
 		products, err := blueprint.CreatesProducts()
 		if err != nil {
 			return nil, err
 		}
+		blueprintCopy := new(Blueprint)
+		*blueprintCopy = blueprint
 		for _, productTypeID := range products {
-			blueprintsCreatingProduct, ok := blueprints[productTypeID]
-			if !ok {
-				blueprintsCreatingProduct = make([]*Blueprint, 0, 1)
-			}
-			blueprintCopy := new(Blueprint)
-			*blueprintCopy = blueprint
-			blueprintsCreatingProduct = append(blueprintsCreatingProduct, blueprintCopy)
-			blueprints[productTypeID] = blueprintsCreatingProduct
+			blueprints[productTypeID] = append(blueprints[productTypeID], blueprintCopy)
 		}
 	}
 
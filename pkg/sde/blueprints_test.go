@@ -5,10 +5,11 @@ import (
 	"os"
 	"testing"
 
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
 	"github.com/stretchr/testify/assert"
 )
 
-func TestImportBlueprints_Smoketest(t *testing.T) {
+func loadTestBlueprints(t *testing.T) ProductBlueprintMap {
 	blueprintsYAMLFile, err := os.Open("../../test/testdata/blueprints.yaml")
 	if err != nil {
 		wd, _ := os.Getwd()
@@ -22,6 +23,13 @@ func TestImportBlueprints_Smoketest(t *testing.T) {
 	t.Logf("loaded %d bytes from blueprint yaml", len(contents))
 
 	blueprints, err := ImportBlueprints([]byte(contents))
+	assert.Nil(t, err)
+
+	return blueprints
+}
+
+func TestImportBlueprints_Smoketest(t *testing.T) {
+	blueprints := loadTestBlueprints(t)
 
 	var totalBlueprints int
 	for _, v := range blueprints {
@@ -29,11 +37,53 @@ func TestImportBlueprints_Smoketest(t *testing.T) {
 	}
 	t.Logf("Loaded %d blueprints", totalBlueprints)
 
-	assert.Nil(t, err)
-
 	for _, productBlueprints := range blueprints {
 		for _, blueprint := range productBlueprints {
 			t.Log(blueprint.BlueprintTypeID)
 		}
 	}
 }
+
+func TestImportBlueprints_AllActivities(t *testing.T) {
+	blueprints := loadTestBlueprints(t)
+
+	rifterBlueprint := blueprints[eveonline.TypeID(587)][0]
+	assert.Equal(t, eveonline.TypeID(691), rifterBlueprint.BlueprintTypeID)
+	assert.Equal(t, 30, rifterBlueprint.MaxProductionLimit)
+	assert.True(t, rifterBlueprint.CanBeBuilt())
+	assert.True(t, rifterBlueprint.CanInvent())
+	assert.Equal(t, 4800, rifterBlueprint.Activities.Copying.TimeInSeconds)
+	assert.Equal(t, 2100, rifterBlueprint.Activities.ResearchMaterial.TimeInSeconds)
+	assert.Equal(t, 2100, rifterBlueprint.Activities.ResearchTime.TimeInSeconds)
+
+	invention := rifterBlueprint.Activities.Activity(eveonline.InventionActivityID)
+	assert.Equal(t, 63900, invention.TimeInSeconds)
+	assert.Len(t, invention.Materials, 2)
+	assert.Len(t, invention.RequiredSkills, 3)
+	assert.Equal(t, eveonline.TypeID(11372), invention.Products[0].TypeID)
+	assert.Equal(t, 0.34, invention.Products[0].Probability)
+
+	// The invented T2 blueprint is built from, not an invention source.
+	inventedBlueprint := blueprints.BlueprintsByTypeID()[11372]
+	assert.False(t, inventedBlueprint.CanInvent())
+	assert.Equal(t, []*Blueprint{rifterBlueprint}, blueprints.InventionSources()[11372])
+
+	reactionBlueprint := blueprints[eveonline.TypeID(16659)][0]
+	assert.True(t, reactionBlueprint.IsReaction())
+	assert.True(t, reactionBlueprint.CanBeBuilt())
+	assert.Equal(t, 10800, reactionBlueprint.Activities.Reaction.TimeInSeconds)
+	assert.Len(t, reactionBlueprint.Activities.Reaction.RequiredSkills, 1)
+}
+
+func TestProductBlueprintMap_ReverseIndexes(t *testing.T) {
+	blueprints := loadTestBlueprints(t)
+
+	sources := blueprints.InventionSources()
+	assert.Len(t, sources[eveonline.TypeID(11372)], 1)
+	assert.Equal(t, eveonline.TypeID(691), sources[eveonline.TypeID(11372)][0].BlueprintTypeID)
+
+	consumers := blueprints.MaterialConsumers()
+	assert.Len(t, consumers[eveonline.TypeID(587)], 1)
+	assert.Equal(t, eveonline.TypeID(11372), consumers[eveonline.TypeID(587)][0].BlueprintTypeID)
+	assert.Len(t, consumers[eveonline.TypeID(16633)], 1)
+}
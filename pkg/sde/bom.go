@@ -0,0 +1,188 @@
+package sde
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+)
+
+type BOMOptions struct {
+	// Build decides whether a buildable type is built or bought.  Nil builds everything that
+	// has a manufacturing or reaction blueprint.
+	Build func(typeID eveonline.TypeID) bool
+	// Materials returns the materials needed for runs of blueprint.  Nil uses the unmodified
+	// SDE quantities; pass a function backed by the industry calculator to apply ME and bonuses.
+	// An error stops the resolution and is returned from ResolveBillOfMaterials.
+	Materials func(blueprint *Blueprint, runs int) ([]TypeQuantity, error)
+}
+
+type BOMNode struct {
+	TypeID      eveonline.TypeID
+	Quantity    int
+	FromSurplus int
+	Blueprint   *Blueprint
+	Runs        int
+	Produced    int
+	Children    []*BOMNode
+}
+
+func (n BOMNode) IsBought() bool {
+	return n.Blueprint == nil
+}
+
+type BillOfMaterials struct {
+	Tree         []*BOMNode
+	ShoppingList []TypeQuantity
+	Runs         []TypeQuantity
+	Excess       []TypeQuantity
+}
+
+type BOMCycleError struct {
+	Path []eveonline.TypeID
+}
+
+func (e BOMCycleError) Error() string {
+	return fmt.Sprintf("Build cycle detected: %v", e.Path)
+}
+
+type bomResolver struct {
+	blueprints ProductBlueprintMap
+	options    BOMOptions
+	surplus    map[eveonline.TypeID]int
+	bought     map[eveonline.TypeID]int
+	runs       map[eveonline.TypeID]int
+}
+
+// ResolveBillOfMaterials recursively expands targets into the jobs and purchases needed to
+// produce them.  Output left over from batch sizes is pooled and consumed by later requirements
+// for the same type before any further runs are scheduled.
+func (m ProductBlueprintMap) ResolveBillOfMaterials(targets []TypeQuantity, options BOMOptions) (*BillOfMaterials, error) {
+	resolver := &bomResolver{
+		blueprints: m,
+		options:    options,
+		surplus:    make(map[eveonline.TypeID]int),
+		bought:     make(map[eveonline.TypeID]int),
+		runs:       make(map[eveonline.TypeID]int),
+	}
+
+	bom := &BillOfMaterials{Tree: make([]*BOMNode, 0, len(targets))}
+	for _, target := range targets {
+		node, err := resolver.resolve(target.TypeID, target.Quantity, nil)
+		if err != nil {
+			return nil, err
+		}
+		bom.Tree = append(bom.Tree, node)
+	}
+
+	bom.ShoppingList = sortedTypeQuantities(resolver.bought)
+	bom.Runs = sortedTypeQuantities(resolver.runs)
+	bom.Excess = sortedTypeQuantities(resolver.surplus)
+
+	return bom, nil
+}
+
+func (r *bomResolver) blueprintFor(typeID eveonline.TypeID) *Blueprint {
+	if r.options.Build != nil && !r.options.Build(typeID) {
+		return nil
+	}
+
+	var chosen *Blueprint
+	for _, blueprint := range r.blueprints[typeID] {
+		if !blueprint.CanBeBuilt() {
+			continue
+		}
+		if chosen == nil || blueprint.BlueprintTypeID < chosen.BlueprintTypeID {
+			chosen = blueprint
+		}
+	}
+	return chosen
+}
+
+func (r *bomResolver) materials(blueprint *Blueprint, runs int) ([]TypeQuantity, error) {
+	if r.options.Materials != nil {
+		return r.options.Materials(blueprint, runs)
+	}
+
+	_, inputs := blueprint.ProductsAndInputs()
+	materials := make([]TypeQuantity, 0, len(inputs))
+	for _, input := range inputs {
+		materials = append(materials, TypeQuantity{TypeID: input.TypeID, Quantity: input.Quantity * runs})
+	}
+	return materials, nil
+}
+
+func (r *bomResolver) resolve(typeID eveonline.TypeID, quantity int, path []eveonline.TypeID) (*BOMNode, error) {
+	for _, ancestor := range path {
+		if ancestor == typeID {
+			cycle := append(append([]eveonline.TypeID{}, path...), typeID)
+			return nil, BOMCycleError{Path: cycle}
+		}
+	}
+
+	node := &BOMNode{TypeID: typeID, Quantity: quantity}
+	fromSurplus := r.surplus[typeID]
+	if fromSurplus > quantity {
+		fromSurplus = quantity
+	}
+	if fromSurplus > 0 {
+		r.surplus[typeID] -= fromSurplus
+		if r.surplus[typeID] == 0 {
+			delete(r.surplus, typeID)
+		}
+	}
+	node.FromSurplus = fromSurplus
+	needed := quantity - fromSurplus
+	if needed == 0 {
+		return node, nil
+	}
+
+	blueprint := r.blueprintFor(typeID)
+	if blueprint == nil {
+		r.bought[typeID] += needed
+		return node, nil
+	}
+
+	perRun := 0
+	products, _ := blueprint.ProductsAndInputs()
+	for _, product := range products {
+		if product.TypeID == typeID {
+			perRun = product.Quantity
+		}
+	}
+	if perRun <= 0 {
+		return nil, fmt.Errorf("Blueprint %d does not produce type %d", blueprint.BlueprintTypeID, typeID)
+	}
+
+	node.Blueprint = blueprint
+	node.Runs = (needed + perRun - 1) / perRun
+	node.Produced = node.Runs * perRun
+	r.runs[blueprint.BlueprintTypeID] += node.Runs
+	if node.Produced > needed {
+		r.surplus[typeID] += node.Produced - needed
+	}
+
+	materials, err := r.materials(blueprint, node.Runs)
+	if err != nil {
+		return nil, err
+	}
+	childPath := append(append([]eveonline.TypeID{}, path...), typeID)
+	for _, material := range materials {
+		child, err := r.resolve(material.TypeID, material.Quantity, childPath)
+		if err != nil {
+			return nil, err
+		}
+		node.Children = append(node.Children, child)
+	}
+
+	return node, nil
+}
+
+func sortedTypeQuantities(quantities map[eveonline.TypeID]int) []TypeQuantity {
+	sorted := make([]TypeQuantity, 0, len(quantities))
+	for typeID, quantity := range quantities {
+		sorted = append(sorted, TypeQuantity{TypeID: typeID, Quantity: quantity})
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].TypeID < sorted[j].TypeID })
+	return sorted
+}
@@ -0,0 +1,75 @@
+package sde
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveBillOfMaterials(t *testing.T) {
+	blueprints := loadTestBlueprints(t)
+
+	bom, err := blueprints.ResolveBillOfMaterials([]TypeQuantity{{TypeID: 11371, Quantity: 2}}, BOMOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, []TypeQuantity{
+		{TypeID: 34, Quantity: 64000},
+		{TypeID: 35, Quantity: 12000},
+		{TypeID: 36, Quantity: 5000},
+		{TypeID: 11399, Quantity: 300},
+	}, bom.ShoppingList)
+	assert.Equal(t, []TypeQuantity{{TypeID: 691, Quantity: 2}, {TypeID: 11372, Quantity: 2}}, bom.Runs)
+	assert.Len(t, bom.Tree, 1)
+	assert.Equal(t, eveonline.TypeID(587), bom.Tree[0].Children[0].TypeID)
+	assert.Equal(t, 2, bom.Tree[0].Children[0].Runs)
+
+	buyHulls := BOMOptions{Build: func(typeID eveonline.TypeID) bool { return typeID != 587 }}
+	bom, err = blueprints.ResolveBillOfMaterials([]TypeQuantity{{TypeID: 11371, Quantity: 2}}, buyHulls)
+	assert.Nil(t, err)
+	assert.Equal(t, []TypeQuantity{{TypeID: 587, Quantity: 2}, {TypeID: 11399, Quantity: 300}}, bom.ShoppingList)
+	assert.True(t, bom.Tree[0].Children[0].IsBought())
+}
+
+func TestResolveBillOfMaterials_PoolsExcess(t *testing.T) {
+	blueprints := loadTestBlueprints(t)
+
+	bom, err := blueprints.ResolveBillOfMaterials(
+		[]TypeQuantity{{TypeID: 16659, Quantity: 250}, {TypeID: 16659, Quantity: 100}},
+		BOMOptions{},
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, []TypeQuantity{{TypeID: 46166, Quantity: 2}}, bom.Runs)
+	assert.Equal(t, []TypeQuantity{{TypeID: 16659, Quantity: 50}}, bom.Excess)
+	assert.Equal(t, 100, bom.Tree[1].FromSurplus)
+	assert.Equal(t, []TypeQuantity{
+		{TypeID: 4051, Quantity: 10},
+		{TypeID: 16633, Quantity: 200},
+		{TypeID: 16636, Quantity: 200},
+	}, bom.ShoppingList)
+}
+
+func TestResolveBillOfMaterials_DetectsCycles(t *testing.T) {
+	a := &Blueprint{BlueprintTypeID: 10, Activities: &Activities{Manufacturing: &Manufacturing{
+		Materials: []TypeQuantity{{TypeID: 2, Quantity: 1}},
+		Products:  []TypeQuantity{{TypeID: 1, Quantity: 1}},
+	}}}
+	b := &Blueprint{BlueprintTypeID: 20, Activities: &Activities{Manufacturing: &Manufacturing{
+		Materials: []TypeQuantity{{TypeID: 1, Quantity: 1}},
+		Products:  []TypeQuantity{{TypeID: 2, Quantity: 1}},
+	}}}
+	blueprints := ProductBlueprintMap{1: []*Blueprint{a}, 2: []*Blueprint{b}}
+
+	_, err := blueprints.ResolveBillOfMaterials([]TypeQuantity{{TypeID: 1, Quantity: 1}}, BOMOptions{})
+	assert.Equal(t, BOMCycleError{Path: []eveonline.TypeID{1, 2, 1}}, err)
+}
+
+func TestResolveBillOfMaterials_MaterialsError(t *testing.T) {
+	blueprints := loadTestBlueprints(t)
+
+	failing := BOMOptions{Materials: func(blueprint *Blueprint, runs int) ([]TypeQuantity, error) {
+		return nil, fmt.Errorf("No facility for blueprint %d", blueprint.BlueprintTypeID)
+	}}
+	_, err := blueprints.ResolveBillOfMaterials([]TypeQuantity{{TypeID: 587, Quantity: 1}}, failing)
+	assert.EqualError(t, err, "No facility for blueprint 691")
+}
@@ -0,0 +1,293 @@
+package sde
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+	yaml "gopkg.in/yaml.v2"
+)
+
+const (
+	MassAttributeID               = eveonline.DogmaAttributeID(4)
+	PowerOutputAttributeID        = eveonline.DogmaAttributeID(11)
+	LowSlotsAttributeID           = eveonline.DogmaAttributeID(12)
+	MedSlotsAttributeID           = eveonline.DogmaAttributeID(13)
+	HiSlotsAttributeID            = eveonline.DogmaAttributeID(14)
+	PowerAttributeID              = eveonline.DogmaAttributeID(30)
+	CPUOutputAttributeID          = eveonline.DogmaAttributeID(48)
+	CPUAttributeID                = eveonline.DogmaAttributeID(50)
+	LauncherSlotsLeftAttributeID  = eveonline.DogmaAttributeID(101)
+	TurretSlotsLeftAttributeID    = eveonline.DogmaAttributeID(102)
+	SkillTimeConstantAttributeID  = eveonline.DogmaAttributeID(275)
+	UpgradeCapacityAttributeID    = eveonline.DogmaAttributeID(1132)
+	RigSlotsAttributeID           = eveonline.DogmaAttributeID(1137)
+	UpgradeCostAttributeID        = eveonline.DogmaAttributeID(1153)
+	RigSizeAttributeID            = eveonline.DogmaAttributeID(1547)
+	MaxSubSystemsAttributeID      = eveonline.DogmaAttributeID(1367)
+	DroneCapacityAttributeID      = eveonline.DogmaAttributeID(283)
+	DroneBandwidthAttributeID     = eveonline.DogmaAttributeID(1271)
+	DroneBandwidthUsedAttributeID = eveonline.DogmaAttributeID(1272)
+
+	JumpDriveConsumptionTypeAttributeID   = eveonline.DogmaAttributeID(866)
+	JumpDriveRangeAttributeID             = eveonline.DogmaAttributeID(867)
+	JumpDriveConsumptionAmountAttributeID = eveonline.DogmaAttributeID(868)
+	JumpFatigueMultiplierAttributeID      = eveonline.DogmaAttributeID(1971)
+)
+
+const (
+	LoPowerEffectID        = eveonline.DogmaEffectID(11)
+	HiPowerEffectID        = eveonline.DogmaEffectID(12)
+	MedPowerEffectID       = eveonline.DogmaEffectID(13)
+	LauncherFittedEffectID = eveonline.DogmaEffectID(40)
+	TurretFittedEffectID   = eveonline.DogmaEffectID(42)
+	RigSlotEffectID        = eveonline.DogmaEffectID(2663)
+	SubSystemEffectID      = eveonline.DogmaEffectID(3772)
+)
+
+// Skill prerequisites are stored as pairs of attributes: the required skill type and its level.
+var requiredSkillAttributeIDs = [][2]eveonline.DogmaAttributeID{
+	{182, 277},
+	{183, 278},
+	{184, 279},
+	{1285, 1286},
+	{1289, 1287},
+	{1290, 1288},
+}
+
+type DogmaAttribute struct {
+	ID           eveonline.DogmaAttributeID `yaml:"attributeID"`
+	Name         string                     `yaml:"name"`
+	DisplayName  LocalizedString            `yaml:"displayNameID"`
+	Description  string                     `yaml:"description"`
+	DefaultValue float64                    `yaml:"defaultValue"`
+	UnitID       int                        `yaml:"unitID"`
+	HighIsGood   bool                       `yaml:"highIsGood"`
+	Stackable    bool                       `yaml:"stackable"`
+	Published    bool                       `yaml:"published"`
+}
+
+type DogmaEffect struct {
+	ID             eveonline.DogmaEffectID `yaml:"effectID"`
+	Name           string                  `yaml:"effectName"`
+	EffectCategory int                     `yaml:"effectCategory"`
+	IsOffensive    bool                    `yaml:"isOffensive"`
+	IsAssistance   bool                    `yaml:"isAssistance"`
+	Published      bool                    `yaml:"published"`
+}
+
+type TypeDogmaAttribute struct {
+	AttributeID eveonline.DogmaAttributeID `yaml:"attributeID"`
+	Value       float64                    `yaml:"value"`
+}
+
+type TypeDogmaEffect struct {
+	EffectID  eveonline.DogmaEffectID `yaml:"effectID"`
+	IsDefault bool                    `yaml:"isDefault"`
+}
+
+type TypeDogma struct {
+	Attributes []TypeDogmaAttribute `yaml:"dogmaAttributes"`
+	Effects    []TypeDogmaEffect    `yaml:"dogmaEffects"`
+}
+
+func ImportDogmaAttributes(dogmaAttributesFileContents []byte) (map[eveonline.DogmaAttributeID]*DogmaAttribute, error) {
+	attributes := make(map[eveonline.DogmaAttributeID]*DogmaAttribute)
+	err := yaml.Unmarshal(dogmaAttributesFileContents, &attributes)
+	if err != nil {
+		return nil, err
+	}
+	return attributes, nil
+}
+
+func ImportDogmaEffects(dogmaEffectsFileContents []byte) (map[eveonline.DogmaEffectID]*DogmaEffect, error) {
+	effects := make(map[eveonline.DogmaEffectID]*DogmaEffect)
+	err := yaml.Unmarshal(dogmaEffectsFileContents, &effects)
+	if err != nil {
+		return nil, err
+	}
+	return effects, nil
+}
+
+func ImportTypeDogma(typeDogmaFileContents []byte) (map[eveonline.TypeID]*TypeDogma, error) {
+	typeDogma := make(map[eveonline.TypeID]*TypeDogma)
+	err := yaml.Unmarshal(typeDogmaFileContents, &typeDogma)
+	if err != nil {
+		return nil, err
+	}
+	return typeDogma, nil
+}
+
+type DogmaStore struct {
+	attributes       map[eveonline.DogmaAttributeID]*DogmaAttribute
+	attributesByName map[string]*DogmaAttribute
+	effects          map[eveonline.DogmaEffectID]*DogmaEffect
+	typeAttributes   map[eveonline.TypeID]map[eveonline.DogmaAttributeID]float64
+	typeEffects      map[eveonline.TypeID]map[eveonline.DogmaEffectID]bool
+}
+
+func NewDogmaStore(
+	attributes map[eveonline.DogmaAttributeID]*DogmaAttribute,
+	effects map[eveonline.DogmaEffectID]*DogmaEffect,
+	typeDogma map[eveonline.TypeID]*TypeDogma,
+) *DogmaStore {
+	store := &DogmaStore{
+		attributes:       attributes,
+		attributesByName: make(map[string]*DogmaAttribute),
+		effects:          effects,
+		typeAttributes:   make(map[eveonline.TypeID]map[eveonline.DogmaAttributeID]float64),
+		typeEffects:      make(map[eveonline.TypeID]map[eveonline.DogmaEffectID]bool),
+	}
+
+	for _, attribute := range attributes {
+		store.attributesByName[attribute.Name] = attribute
+	}
+	for typeID, dogma := range typeDogma {
+		typeAttributes := make(map[eveonline.DogmaAttributeID]float64)
+		for _, attribute := range dogma.Attributes {
+			typeAttributes[attribute.AttributeID] = attribute.Value
+		}
+		store.typeAttributes[typeID] = typeAttributes
+
+		typeEffects := make(map[eveonline.DogmaEffectID]bool)
+		for _, effect := range dogma.Effects {
+			typeEffects[effect.EffectID] = true
+		}
+		store.typeEffects[typeID] = typeEffects
+	}
+
+	return store
+}
+
+func (s *DogmaStore) Attribute(attributeID eveonline.DogmaAttributeID) (*DogmaAttribute, bool) {
+	attribute, ok := s.attributes[attributeID]
+	return attribute, ok
+}
+
+func (s *DogmaStore) AttributeByName(name string) (*DogmaAttribute, bool) {
+	attribute, ok := s.attributesByName[name]
+	return attribute, ok
+}
+
+func (s *DogmaStore) Effect(effectID eveonline.DogmaEffectID) (*DogmaEffect, bool) {
+	effect, ok := s.effects[effectID]
+	return effect, ok
+}
+
+func (s *DogmaStore) HasType(typeID eveonline.TypeID) bool {
+	_, ok := s.typeAttributes[typeID]
+	return ok
+}
+
+// TypeAttribute returns the value of an attribute on a type, falling back to the attribute's
+// default when the type does not set it.  ok is false for unknown types and attributes.
+func (s *DogmaStore) TypeAttribute(typeID eveonline.TypeID, attributeID eveonline.DogmaAttributeID) (float64, bool) {
+	typeAttributes, ok := s.typeAttributes[typeID]
+	if !ok {
+		return 0.0, false
+	}
+	if value, ok := typeAttributes[attributeID]; ok {
+		return value, true
+	}
+	attribute, ok := s.attributes[attributeID]
+	if !ok {
+		return 0.0, false
+	}
+	return attribute.DefaultValue, true
+}
+
+func (s *DogmaStore) TypeAttributeByName(typeID eveonline.TypeID, name string) (float64, bool) {
+	attribute, ok := s.attributesByName[name]
+	if !ok {
+		return 0.0, false
+	}
+	return s.TypeAttribute(typeID, attribute.ID)
+}
+
+func (s *DogmaStore) TypeHasEffect(typeID eveonline.TypeID, effectID eveonline.DogmaEffectID) bool {
+	return s.typeEffects[typeID][effectID]
+}
+
+func (s *DogmaStore) SkillRank(skillTypeID eveonline.TypeID) (int, bool) {
+	rank, ok := s.TypeAttribute(skillTypeID, SkillTimeConstantAttributeID)
+	return int(rank), ok
+}
+
+func (s *DogmaStore) SkillPrerequisites(typeID eveonline.TypeID) []SkillLevel {
+	prerequisites := make([]SkillLevel, 0)
+	typeAttributes := s.typeAttributes[typeID]
+	for _, pair := range requiredSkillAttributeIDs {
+		skillID, ok := typeAttributes[pair[0]]
+		if !ok || skillID == 0 {
+			continue
+		}
+		prerequisites = append(prerequisites, SkillLevel{
+			SkillID: eveonline.TypeID(skillID),
+			Level:   int(typeAttributes[pair[1]]),
+		})
+	}
+	return prerequisites
+}
+
+func (s *DogmaStore) FormatTypeAttribute(typeID eveonline.TypeID, attributeID eveonline.DogmaAttributeID) (string, bool) {
+	attribute, ok := s.attributes[attributeID]
+	if !ok {
+		return "", false
+	}
+	value, ok := s.TypeAttribute(typeID, attributeID)
+	if !ok {
+		return "", false
+	}
+	return FormatAttributeValue(attribute.UnitID, value), true
+}
+
+var unitSuffixes = map[int]string{
+	1:   "m",
+	2:   "kg",
+	3:   "s",
+	9:   "m3",
+	10:  "m/s",
+	104: "x",
+	105: "%",
+	106: "tf",
+	107: "MW",
+	112: "rad/s",
+	113: "HP",
+	114: "GJ",
+	120: "points",
+	121: "%",
+	124: "%",
+	133: "ISK",
+	140: "Level",
+}
+
+// FormatAttributeValue renders a dogma value the way the client shows it for the given unit,
+// converting the percentage and resonance units to the number players expect.
+func FormatAttributeValue(unitID int, value float64) string {
+	switch unitID {
+	case 101:
+		return fmt.Sprintf("%s s", formatNumber(value/1000))
+	case 108, 111:
+		return fmt.Sprintf("%s %%", formatNumber((1-value)*100))
+	case 109:
+		return fmt.Sprintf("%s %%", formatNumber((value-1)*100))
+	case 127:
+		return fmt.Sprintf("%s %%", formatNumber(value*100))
+	case 137:
+		if value != 0 {
+			return "True"
+		}
+		return "False"
+	}
+
+	suffix, ok := unitSuffixes[unitID]
+	if !ok {
+		return formatNumber(value)
+	}
+	return fmt.Sprintf("%s %s", formatNumber(value), suffix)
+}
+
+func formatNumber(value float64) string {
+	return strconv.FormatFloat(math.Round(value*100)/100, 'f', -1, 64)
+}
@@ -0,0 +1,96 @@
+package sde
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+	"github.com/stretchr/testify/assert"
+)
+
+func loadTestDogmaStore(t *testing.T) *DogmaStore {
+	attributesContents, err := ioutil.ReadFile("../../test/testdata/dogmaAttributes.yaml")
+	if err != nil {
+		t.Fatalf("Failed to read dogmaAttributes YAML test data: %v", err)
+	}
+	effectsContents, err := ioutil.ReadFile("../../test/testdata/dogmaEffects.yaml")
+	if err != nil {
+		t.Fatalf("Failed to read dogmaEffects YAML test data: %v", err)
+	}
+	typeDogmaContents, err := ioutil.ReadFile("../../test/testdata/typeDogma.yaml")
+	if err != nil {
+		t.Fatalf("Failed to read typeDogma YAML test data: %v", err)
+	}
+
+	attributes, err := ImportDogmaAttributes(attributesContents)
+	assert.Nil(t, err)
+	effects, err := ImportDogmaEffects(effectsContents)
+	assert.Nil(t, err)
+	typeDogma, err := ImportTypeDogma(typeDogmaContents)
+	assert.Nil(t, err)
+
+	return NewDogmaStore(attributes, effects, typeDogma)
+}
+
+func TestDogmaStore(t *testing.T) {
+	store := loadTestDogmaStore(t)
+
+	hiSlots, ok := store.TypeAttribute(587, HiSlotsAttributeID)
+	assert.True(t, ok)
+	assert.Equal(t, 4.0, hiSlots)
+
+	cpuOutput, ok := store.TypeAttributeByName(587, "cpuOutput")
+	assert.True(t, ok)
+	assert.Equal(t, 130.0, cpuOutput)
+
+	// Not set on the Rifter, so the attribute default applies.
+	rank, ok := store.TypeAttribute(587, SkillTimeConstantAttributeID)
+	assert.True(t, ok)
+	assert.Equal(t, 1.0, rank)
+
+	_, ok = store.TypeAttribute(1, HiSlotsAttributeID)
+	assert.False(t, ok)
+	_, ok = store.TypeAttributeByName(587, "notAnAttribute")
+	assert.False(t, ok)
+
+	assert.True(t, store.TypeHasEffect(2873, HiPowerEffectID))
+	assert.True(t, store.TypeHasEffect(2873, TurretFittedEffectID))
+	assert.False(t, store.TypeHasEffect(2873, LoPowerEffectID))
+
+	effect, ok := store.Effect(RigSlotEffectID)
+	assert.True(t, ok)
+	assert.Equal(t, "rigSlot", effect.Name)
+}
+
+func TestDogmaStore_Skills(t *testing.T) {
+	store := loadTestDogmaStore(t)
+
+	rank, ok := store.SkillRank(3388)
+	assert.True(t, ok)
+	assert.Equal(t, 3, rank)
+	assert.Equal(t, []SkillLevel{{SkillID: 3380, Level: 5}}, store.SkillPrerequisites(3388))
+	assert.Equal(t, []SkillLevel{}, store.SkillPrerequisites(3380))
+}
+
+func TestFormatAttributeValue(t *testing.T) {
+	store := loadTestDogmaStore(t)
+
+	tests := []struct {
+		typeID      eveonline.TypeID
+		attributeID eveonline.DogmaAttributeID
+		expected    string
+	}{
+		{587, PowerOutputAttributeID, "41 MW"},
+		{587, CPUOutputAttributeID, "130 tf"},
+		{587, MassAttributeID, "1067000 kg"},
+		{587, eveonline.DogmaAttributeID(974), "33 %"},
+		{2873, eveonline.DogmaAttributeID(73), "2.1 s"},
+		{587, HiSlotsAttributeID, "4"},
+	}
+
+	for _, test := range tests {
+		formatted, ok := store.FormatTypeAttribute(test.typeID, test.attributeID)
+		assert.True(t, ok)
+		assert.Equal(t, test.expected, formatted)
+	}
+}
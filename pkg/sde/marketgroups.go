@@ -0,0 +1,30 @@
+package sde
+
+import (
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+	yaml "gopkg.in/yaml.v2"
+)
+
+type MarketGroup struct {
+	ID            eveonline.MarketGroupID  `yaml:"-"`
+	Name          LocalizedString          `yaml:"nameID"`
+	Description   LocalizedString          `yaml:"descriptionID"`
+	IconID        int64                    `yaml:"iconID"`
+	ParentGroupID *eveonline.MarketGroupID `yaml:"parentGroupID"`
+	// HasTypes is set on leaf groups, the only ones types are listed under in game.
+	HasTypes bool `yaml:"hasTypes"`
+}
+
+func ImportMarketGroups(marketGroupsFileContents []byte) (map[eveonline.MarketGroupID]*MarketGroup, error) {
+	marketGroups := make(map[eveonline.MarketGroupID]*MarketGroup)
+	err := yaml.Unmarshal(marketGroupsFileContents, &marketGroups)
+	if err != nil {
+		return nil, err
+	}
+
+	for marketGroupID, marketGroup := range marketGroups {
+		marketGroup.ID = marketGroupID
+	}
+
+	return marketGroups, nil
+}
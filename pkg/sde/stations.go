@@ -0,0 +1,36 @@
+package sde
+
+import (
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+	yaml "gopkg.in/yaml.v2"
+)
+
+type Station struct {
+	ID                     eveonline.StationID       `yaml:"stationID"`
+	Name                   string                    `yaml:"stationName"`
+	TypeID                 eveonline.TypeID          `yaml:"stationTypeID"`
+	CorporationID          eveonline.CorporationID   `yaml:"corporationID"`
+	SystemID               eveonline.SystemID        `yaml:"solarSystemID"`
+	ConstellationID        eveonline.ConstellationID `yaml:"constellationID"`
+	RegionID               eveonline.RegionID        `yaml:"regionID"`
+	Security               float64                   `yaml:"security"`
+	ReprocessingEfficiency float64                   `yaml:"reprocessingEfficiency"`
+	X                      float64                   `yaml:"x"`
+	Y                      float64                   `yaml:"y"`
+	Z                      float64                   `yaml:"z"`
+}
+
+func ImportStations(staStationsFileContents []byte) (map[eveonline.StationID]*Station, error) {
+	stationList := make([]*Station, 0)
+	err := yaml.Unmarshal(staStationsFileContents, &stationList)
+	if err != nil {
+		return nil, err
+	}
+
+	stations := make(map[eveonline.StationID]*Station)
+	for _, station := range stationList {
+		stations[station.ID] = station
+	}
+
+	return stations, nil
+}
@@ -0,0 +1,25 @@
+package sde
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportStations(t *testing.T) {
+	contents, err := ioutil.ReadFile("../../test/testdata/staStations.yaml")
+	if err != nil {
+		t.Fatalf("Failed to read staStations YAML test data: %v", err)
+	}
+
+	stations, err := ImportStations(contents)
+	assert.Nil(t, err)
+	assert.Len(t, stations, 2)
+
+	jita := stations[60003760]
+	assert.Equal(t, "Jita IV - Moon 4 - Caldari Navy Assembly Plant", jita.Name)
+	assert.Equal(t, eveonline.SystemID(30000142), jita.SystemID)
+	assert.Equal(t, eveonline.RegionID(10000002), jita.RegionID)
+}
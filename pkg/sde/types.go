@@ -0,0 +1,184 @@
+package sde
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+	yaml "gopkg.in/yaml.v2"
+)
+
+type LocalizedString map[string]string
+
+// String returns the English text, which every localized SDE field carries.
+func (l LocalizedString) String() string {
+	return l["en"]
+}
+
+type Type struct {
+	ID            eveonline.TypeID         `yaml:"-"`
+	GroupID       eveonline.GroupID        `yaml:"groupID"`
+	Name          LocalizedString          `yaml:"name"`
+	Description   LocalizedString          `yaml:"description"`
+	Volume        float64                  `yaml:"volume"`
+	Mass          float64                  `yaml:"mass"`
+	Capacity      float64                  `yaml:"capacity"`
+	PortionSize   int                      `yaml:"portionSize"`
+	BasePrice     float64                  `yaml:"basePrice"`
+	MarketGroupID *eveonline.MarketGroupID `yaml:"marketGroupID"`
+	MetaGroupID   *eveonline.MetaGroupID   `yaml:"metaGroupID"`
+	Published     bool                     `yaml:"published"`
+}
+
+type Group struct {
+	ID         eveonline.GroupID    `yaml:"-"`
+	CategoryID eveonline.CategoryID `yaml:"categoryID"`
+	Name       LocalizedString      `yaml:"name"`
+	Published  bool                 `yaml:"published"`
+}
+
+type Category struct {
+	ID        eveonline.CategoryID `yaml:"-"`
+	Name      LocalizedString      `yaml:"name"`
+	Published bool                 `yaml:"published"`
+}
+
+func ImportTypes(typeIDsFileContents []byte) (map[eveonline.TypeID]*Type, error) {
+	types := make(map[eveonline.TypeID]*Type)
+	err := yaml.Unmarshal(typeIDsFileContents, &types)
+	if err != nil {
+		return nil, err
+	}
+
+	for typeID, typeObj := range types {
+		typeObj.ID = typeID
+	}
+
+	return types, nil
+}
+
+func ImportGroups(groupIDsFileContents []byte) (map[eveonline.GroupID]*Group, error) {
+	groups := make(map[eveonline.GroupID]*Group)
+	err := yaml.Unmarshal(groupIDsFileContents, &groups)
+	if err != nil {
+		return nil, err
+	}
+
+	for groupID, group := range groups {
+		group.ID = groupID
+	}
+
+	return groups, nil
+}
+
+func ImportCategories(categoryIDsFileContents []byte) (map[eveonline.CategoryID]*Category, error) {
+	categories := make(map[eveonline.CategoryID]*Category)
+	err := yaml.Unmarshal(categoryIDsFileContents, &categories)
+	if err != nil {
+		return nil, err
+	}
+
+	for categoryID, category := range categories {
+		category.ID = categoryID
+	}
+
+	return categories, nil
+}
+
+type TypeStore struct {
+	types            map[eveonline.TypeID]*Type
+	groups           map[eveonline.GroupID]*Group
+	categories       map[eveonline.CategoryID]*Category
+	typesByGroup     map[eveonline.GroupID][]eveonline.TypeID
+	groupsByCategory map[eveonline.CategoryID][]eveonline.GroupID
+	typesByName      map[string]eveonline.TypeID
+	typesByMarket    map[eveonline.MarketGroupID][]eveonline.TypeID
+}
+
+func NewTypeStore(
+	types map[eveonline.TypeID]*Type,
+	groups map[eveonline.GroupID]*Group,
+	categories map[eveonline.CategoryID]*Category,
+) *TypeStore {
+	store := &TypeStore{
+		types:            types,
+		groups:           groups,
+		categories:       categories,
+		typesByGroup:     make(map[eveonline.GroupID][]eveonline.TypeID),
+		groupsByCategory: make(map[eveonline.CategoryID][]eveonline.GroupID),
+		typesByName:      make(map[string]eveonline.TypeID),
+		typesByMarket:    make(map[eveonline.MarketGroupID][]eveonline.TypeID),
+	}
+
+	for typeID, typeObj := range types {
+		store.typesByGroup[typeObj.GroupID] = append(store.typesByGroup[typeObj.GroupID], typeID)
+		name := strings.ToLower(typeObj.Name.String())
+		// Prefer published types when unpublished duplicates share a name, then the lowest id so
+		// the choice does not depend on map order.
+		existingTypeID, ok := store.typesByName[name]
+		if !ok {
+			store.typesByName[name] = typeID
+		} else if existing := types[existingTypeID]; existing.Published != typeObj.Published {
+			if typeObj.Published {
+				store.typesByName[name] = typeID
+			}
+		} else if typeID < existingTypeID {
+			store.typesByName[name] = typeID
+		}
+		if typeObj.MarketGroupID != nil {
+			store.typesByMarket[*typeObj.MarketGroupID] = append(store.typesByMarket[*typeObj.MarketGroupID], typeID)
+		}
+	}
+	for groupID, group := range groups {
+		store.groupsByCategory[group.CategoryID] = append(store.groupsByCategory[group.CategoryID], groupID)
+	}
+	for _, typeIDs := range store.typesByGroup {
+		sort.Slice(typeIDs, func(i, j int) bool { return typeIDs[i] < typeIDs[j] })
+	}
+	for _, typeIDs := range store.typesByMarket {
+		sort.Slice(typeIDs, func(i, j int) bool { return typeIDs[i] < typeIDs[j] })
+	}
+	for _, groupIDs := range store.groupsByCategory {
+		sort.Slice(groupIDs, func(i, j int) bool { return groupIDs[i] < groupIDs[j] })
+	}
+
+	return store
+}
+
+func (s *TypeStore) Type(typeID eveonline.TypeID) (*Type, bool) {
+	typeObj, ok := s.types[typeID]
+	return typeObj, ok
+}
+
+func (s *TypeStore) Group(groupID eveonline.GroupID) (*Group, bool) {
+	group, ok := s.groups[groupID]
+	return group, ok
+}
+
+func (s *TypeStore) Category(categoryID eveonline.CategoryID) (*Category, bool) {
+	category, ok := s.categories[categoryID]
+	return category, ok
+}
+
+// TypeIDsInGroup, GroupIDsInCategory and TypeIDsInMarketGroup return copies, so callers may
+// modify the result without corrupting the store.
+func (s *TypeStore) TypeIDsInGroup(groupID eveonline.GroupID) []eveonline.TypeID {
+	return append([]eveonline.TypeID{}, s.typesByGroup[groupID]...)
+}
+
+func (s *TypeStore) GroupIDsInCategory(categoryID eveonline.CategoryID) []eveonline.GroupID {
+	return append([]eveonline.GroupID{}, s.groupsByCategory[categoryID]...)
+}
+
+func (s *TypeStore) TypeIDsInMarketGroup(marketGroupID eveonline.MarketGroupID) []eveonline.TypeID {
+	return append([]eveonline.TypeID{}, s.typesByMarket[marketGroupID]...)
+}
+
+// TypeByName looks up a type by its English name, ignoring case.
+func (s *TypeStore) TypeByName(name string) (*Type, bool) {
+	typeID, ok := s.typesByName[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return nil, false
+	}
+	return s.types[typeID], true
+}
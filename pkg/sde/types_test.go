@@ -0,0 +1,84 @@
+package sde
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+	"github.com/stretchr/testify/assert"
+)
+
+func loadTestTypeStore(t *testing.T) *TypeStore {
+	typeIDs, err := ioutil.ReadFile("../../test/testdata/typeIDs.yaml")
+	if err != nil {
+		t.Fatalf("Failed to read typeIDs YAML test data: %v", err)
+	}
+	groupIDs, err := ioutil.ReadFile("../../test/testdata/groupIDs.yaml")
+	if err != nil {
+		t.Fatalf("Failed to read groupIDs YAML test data: %v", err)
+	}
+	categoryIDs, err := ioutil.ReadFile("../../test/testdata/categoryIDs.yaml")
+	if err != nil {
+		t.Fatalf("Failed to read categoryIDs YAML test data: %v", err)
+	}
+
+	types, err := ImportTypes(typeIDs)
+	assert.Nil(t, err)
+	groups, err := ImportGroups(groupIDs)
+	assert.Nil(t, err)
+	categories, err := ImportCategories(categoryIDs)
+	assert.Nil(t, err)
+
+	return NewTypeStore(types, groups, categories)
+}
+
+func TestTypeStore(t *testing.T) {
+	store := loadTestTypeStore(t)
+
+	rifter, ok := store.Type(587)
+	assert.True(t, ok)
+	assert.Equal(t, eveonline.TypeID(587), rifter.ID)
+	assert.Equal(t, "Rifter", rifter.Name.String())
+	assert.Equal(t, 27289.0, rifter.Volume)
+	assert.Equal(t, eveonline.MarketGroupID(64), *rifter.MarketGroupID)
+	assert.Equal(t, eveonline.MetaGroupID(1), *rifter.MetaGroupID)
+
+	frigates, ok := store.Group(25)
+	assert.True(t, ok)
+	assert.Equal(t, eveonline.CategoryID(6), frigates.CategoryID)
+	assert.Equal(t, []eveonline.TypeID{34, 35}, store.TypeIDsInGroup(18))
+	assert.Equal(t, []eveonline.GroupID{25, 324}, store.GroupIDsInCategory(6))
+	assert.Equal(t, []eveonline.TypeID{34, 35}, store.TypeIDsInMarketGroup(1857))
+
+	skills, ok := store.Category(eveonline.SkillCategoryID)
+	assert.True(t, ok)
+	assert.Equal(t, "Skill", skills.Name.String())
+
+	tritanium, ok := store.TypeByName(" tritanium ")
+	assert.True(t, ok)
+	assert.Equal(t, eveonline.TypeID(34), tritanium.ID)
+
+	_, ok = store.Type(1)
+	assert.False(t, ok)
+}
+
+func TestTypeStoreDuplicateNames(t *testing.T) {
+	name := LocalizedString{"en": "Tritanium"}
+	types := map[eveonline.TypeID]*Type{
+		900: {ID: 900, GroupID: 18, Name: name, Published: true},
+		34:  {ID: 34, GroupID: 18, Name: name, Published: true},
+		10:  {ID: 10, GroupID: 18, Name: name},
+	}
+
+	for i := 0; i < 20; i++ {
+		store := NewTypeStore(types, nil, nil)
+		tritanium, ok := store.TypeByName("Tritanium")
+		assert.True(t, ok)
+		assert.Equal(t, eveonline.TypeID(34), tritanium.ID)
+	}
+
+	store := NewTypeStore(types, nil, nil)
+	typeIDs := store.TypeIDsInGroup(18)
+	typeIDs[0] = 1
+	assert.Equal(t, []eveonline.TypeID{10, 34, 900}, store.TypeIDsInGroup(18))
+}
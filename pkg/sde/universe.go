@@ -0,0 +1,376 @@
+package sde
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+	yaml "gopkg.in/yaml.v2"
+)
+
+const (
+	regionFileName        = "region.staticdata"
+	constellationFileName = "constellation.staticdata"
+	solarSystemFileName   = "solarsystem.staticdata"
+)
+
+type Position struct {
+	X float64
+	Y float64
+	Z float64
+}
+
+// The SDE stores coordinates as a three element list.
+func (p *Position) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var coordinates []float64
+	err := unmarshal(&coordinates)
+	if err != nil {
+		return err
+	}
+	if len(coordinates) != 3 {
+		return fmt.Errorf("Expected 3 coordinates, got %d", len(coordinates))
+	}
+	p.X, p.Y, p.Z = coordinates[0], coordinates[1], coordinates[2]
+	return nil
+}
+
+func (p Position) DistanceTo(other Position) float64 {
+	dx, dy, dz := p.X-other.X, p.Y-other.Y, p.Z-other.Z
+	return math.Sqrt(dx*dx + dy*dy + dz*dz)
+}
+
+type Region struct {
+	ID               eveonline.RegionID          `yaml:"regionID"`
+	Name             string                      `yaml:"-"`
+	NameID           int64                       `yaml:"nameID"`
+	FactionID        int64                       `yaml:"factionID"`
+	Center           Position                    `yaml:"center"`
+	ConstellationIDs []eveonline.ConstellationID `yaml:"-"`
+}
+
+type Constellation struct {
+	ID        eveonline.ConstellationID `yaml:"constellationID"`
+	Name      string                    `yaml:"-"`
+	NameID    int64                     `yaml:"nameID"`
+	Center    Position                  `yaml:"center"`
+	RegionID  eveonline.RegionID        `yaml:"-"`
+	SystemIDs []eveonline.SystemID      `yaml:"-"`
+}
+
+type Stargate struct {
+	ID                  eveonline.StargateID `yaml:"-"`
+	TypeID              eveonline.TypeID     `yaml:"typeID"`
+	DestinationID       eveonline.StargateID `yaml:"destination"`
+	Position            Position             `yaml:"position"`
+	SystemID            eveonline.SystemID   `yaml:"-"`
+	DestinationSystemID eveonline.SystemID   `yaml:"-"`
+}
+
+type sdeMoon struct {
+	NPCStations map[eveonline.StationID]interface{} `yaml:"npcStations"`
+}
+
+type sdePlanet struct {
+	NPCStations map[eveonline.StationID]interface{} `yaml:"npcStations"`
+	Moons       map[int64]sdeMoon                   `yaml:"moons"`
+}
+
+type SolarSystem struct {
+	ID              eveonline.SystemID                 `yaml:"solarSystemID"`
+	Name            string                             `yaml:"-"`
+	NameID          int64                              `yaml:"solarSystemNameID"`
+	Security        float64                            `yaml:"security"`
+	SecurityClass   string                             `yaml:"securityClass"`
+	Center          Position                           `yaml:"center"`
+	Hub             bool                               `yaml:"hub"`
+	Border          bool                               `yaml:"border"`
+	Regional        bool                               `yaml:"regional"`
+	WormholeClassID int                                `yaml:"wormholeClassID"`
+	Stargates       map[eveonline.StargateID]*Stargate `yaml:"stargates"`
+	Planets         map[int64]sdePlanet                `yaml:"planets"`
+	StationIDs      []eveonline.StationID              `yaml:"-"`
+	ConstellationID eveonline.ConstellationID          `yaml:"-"`
+	RegionID        eveonline.RegionID                 `yaml:"-"`
+}
+
+func ImportRegion(regionFileContents []byte) (*Region, error) {
+	region := new(Region)
+	err := yaml.Unmarshal(regionFileContents, region)
+	if err != nil {
+		return nil, err
+	}
+	return region, nil
+}
+
+func ImportConstellation(constellationFileContents []byte) (*Constellation, error) {
+	constellation := new(Constellation)
+	err := yaml.Unmarshal(constellationFileContents, constellation)
+	if err != nil {
+		return nil, err
+	}
+	return constellation, nil
+}
+
+func ImportSolarSystem(solarSystemFileContents []byte) (*SolarSystem, error) {
+	system := new(SolarSystem)
+	err := yaml.Unmarshal(solarSystemFileContents, system)
+	if err != nil {
+		return nil, err
+	}
+
+	for stargateID, stargate := range system.Stargates {
+		stargate.ID = stargateID
+		stargate.SystemID = system.ID
+	}
+	for _, planet := range system.Planets {
+		for stationID := range planet.NPCStations {
+			system.StationIDs = append(system.StationIDs, stationID)
+		}
+		for _, moon := range planet.Moons {
+			for stationID := range moon.NPCStations {
+				system.StationIDs = append(system.StationIDs, stationID)
+			}
+		}
+	}
+	sort.Slice(system.StationIDs, func(i, j int) bool { return system.StationIDs[i] < system.StationIDs[j] })
+	system.Planets = nil
+
+	return system, nil
+}
+
+type invName struct {
+	ItemID   int64  `yaml:"itemID"`
+	ItemName string `yaml:"itemName"`
+}
+
+// ImportNames loads invNames.yaml, which holds the names of regions, constellations, systems and
+// stations.
+func ImportNames(invNamesFileContents []byte) (map[int64]string, error) {
+	nameList := make([]invName, 0)
+	err := yaml.Unmarshal(invNamesFileContents, &nameList)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[int64]string)
+	for _, name := range nameList {
+		names[name.ItemID] = name.ItemName
+	}
+	return names, nil
+}
+
+type Universe struct {
+	Regions        map[eveonline.RegionID]*Region
+	Constellations map[eveonline.ConstellationID]*Constellation
+	Systems        map[eveonline.SystemID]*SolarSystem
+	Stargates      map[eveonline.StargateID]*Stargate
+	Stations       map[eveonline.StationID]*Station
+	// DanglingStargateIDs lists gates whose destination was not loaded, as happens when loading
+	// a single region.  Link leaves them out of the system graph.
+	DanglingStargateIDs []eveonline.StargateID
+	systemsByName       map[string]eveonline.SystemID
+	neighbours          map[eveonline.SystemID][]eveonline.SystemID
+}
+
+func NewUniverse() *Universe {
+	return &Universe{
+		Regions:        make(map[eveonline.RegionID]*Region),
+		Constellations: make(map[eveonline.ConstellationID]*Constellation),
+		Systems:        make(map[eveonline.SystemID]*SolarSystem),
+		Stargates:      make(map[eveonline.StargateID]*Stargate),
+		Stations:       make(map[eveonline.StationID]*Station),
+		systemsByName:  make(map[string]eveonline.SystemID),
+		neighbours:     make(map[eveonline.SystemID][]eveonline.SystemID),
+	}
+}
+
+func (u *Universe) AddRegion(region *Region) {
+	u.Regions[region.ID] = region
+}
+
+func (u *Universe) AddConstellation(constellation *Constellation, regionID eveonline.RegionID) {
+	constellation.RegionID = regionID
+	u.Constellations[constellation.ID] = constellation
+	if region, ok := u.Regions[regionID]; ok {
+		region.ConstellationIDs = append(region.ConstellationIDs, constellation.ID)
+	}
+}
+
+func (u *Universe) AddSolarSystem(system *SolarSystem, constellationID eveonline.ConstellationID) {
+	system.ConstellationID = constellationID
+	if constellation, ok := u.Constellations[constellationID]; ok {
+		system.RegionID = constellation.RegionID
+		constellation.SystemIDs = append(constellation.SystemIDs, system.ID)
+	}
+	u.Systems[system.ID] = system
+	for _, stargate := range system.Stargates {
+		u.Stargates[stargate.ID] = stargate
+	}
+}
+
+// AddStations attaches NPC stations from staStations.yaml.  Their names are kept as loaded.
+func (u *Universe) AddStations(stations map[eveonline.StationID]*Station) {
+	for stationID, station := range stations {
+		u.Stations[stationID] = station
+	}
+}
+
+// Link resolves stargate destinations into the system graph and builds the name index.  Call it
+// once after everything has been added.
+func (u *Universe) Link() {
+	u.neighbours = make(map[eveonline.SystemID][]eveonline.SystemID)
+	u.DanglingStargateIDs = make([]eveonline.StargateID, 0)
+	for _, stargate := range u.Stargates {
+		destination, ok := u.Stargates[stargate.DestinationID]
+		if !ok {
+			u.DanglingStargateIDs = append(u.DanglingStargateIDs, stargate.ID)
+			continue
+		}
+		stargate.DestinationSystemID = destination.SystemID
+		u.neighbours[stargate.SystemID] = append(u.neighbours[stargate.SystemID], destination.SystemID)
+	}
+	for _, neighbours := range u.neighbours {
+		sort.Slice(neighbours, func(i, j int) bool { return neighbours[i] < neighbours[j] })
+	}
+	sort.Slice(u.DanglingStargateIDs, func(i, j int) bool { return u.DanglingStargateIDs[i] < u.DanglingStargateIDs[j] })
+
+	u.systemsByName = make(map[string]eveonline.SystemID)
+	for systemID, system := range u.Systems {
+		u.systemsByName[strings.ToLower(system.Name)] = systemID
+	}
+
+	for _, region := range u.Regions {
+		sort.Slice(region.ConstellationIDs, func(i, j int) bool { return region.ConstellationIDs[i] < region.ConstellationIDs[j] })
+	}
+	for _, constellation := range u.Constellations {
+		sort.Slice(constellation.SystemIDs, func(i, j int) bool { return constellation.SystemIDs[i] < constellation.SystemIDs[j] })
+	}
+}
+
+func (u *Universe) Neighbours(systemID eveonline.SystemID) []eveonline.SystemID {
+	return u.neighbours[systemID]
+}
+
+func (u *Universe) SystemByName(name string) (*SolarSystem, bool) {
+	systemID, ok := u.systemsByName[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return nil, false
+	}
+	return u.Systems[systemID], true
+}
+
+type StationLocation struct {
+	Station       *Station
+	System        *SolarSystem
+	Constellation *Constellation
+	Region        *Region
+}
+
+func (u *Universe) LocateStation(stationID eveonline.StationID) (*StationLocation, bool) {
+	station, ok := u.Stations[stationID]
+	if !ok {
+		return nil, false
+	}
+	system, ok := u.Systems[station.SystemID]
+	if !ok {
+		return nil, false
+	}
+	return &StationLocation{
+		Station:       station,
+		System:        system,
+		Constellation: u.Constellations[system.ConstellationID],
+		Region:        u.Regions[system.RegionID],
+	}, true
+}
+
+// LoadUniverse walks the fsd/universe directory of an unpacked SDE.  Names come from names,
+// loaded with ImportNames, falling back to the directory names when an id is missing.
+func LoadUniverse(universeDir string, names map[int64]string) (*Universe, error) {
+	regionDirs := make(map[string]*Region)
+	constellationDirs := make(map[string]*Constellation)
+	systemDirs := make(map[string]*SolarSystem)
+
+	err := filepath.Walk(universeDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		dir := filepath.Dir(path)
+		switch info.Name() {
+		case regionFileName, constellationFileName, solarSystemFileName:
+		default:
+			return nil
+		}
+
+		contents, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		switch info.Name() {
+		case regionFileName:
+			region, err := ImportRegion(contents)
+			if err != nil {
+				return fmt.Errorf("Failed to import %s, %v", path, err)
+			}
+			region.Name = nameOrDirectory(names, int64(region.ID), dir)
+			regionDirs[dir] = region
+		case constellationFileName:
+			constellation, err := ImportConstellation(contents)
+			if err != nil {
+				return fmt.Errorf("Failed to import %s, %v", path, err)
+			}
+			constellation.Name = nameOrDirectory(names, int64(constellation.ID), dir)
+			constellationDirs[dir] = constellation
+		case solarSystemFileName:
+			system, err := ImportSolarSystem(contents)
+			if err != nil {
+				return fmt.Errorf("Failed to import %s, %v", path, err)
+			}
+			system.Name = nameOrDirectory(names, int64(system.ID), dir)
+			systemDirs[dir] = system
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	universe := NewUniverse()
+	for _, region := range regionDirs {
+		universe.AddRegion(region)
+	}
+	for dir, constellation := range constellationDirs {
+		region, ok := regionDirs[filepath.Dir(dir)]
+		if !ok {
+			return nil, fmt.Errorf("Constellation %d in %s is not inside a region", constellation.ID, dir)
+		}
+		universe.AddConstellation(constellation, region.ID)
+	}
+	for dir, system := range systemDirs {
+		constellation, ok := constellationDirs[filepath.Dir(dir)]
+		if !ok {
+			return nil, fmt.Errorf("Solar system %d in %s is not inside a constellation", system.ID, dir)
+		}
+		universe.AddSolarSystem(system, constellation.ID)
+	}
+
+	universe.Link()
+
+	return universe, nil
+}
+
+func nameOrDirectory(names map[int64]string, id int64, dir string) string {
+	if name, ok := names[id]; ok {
+		return name
+	}
+	return filepath.Base(dir)
+}
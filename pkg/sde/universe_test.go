@@ -0,0 +1,95 @@
+package sde
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+	"github.com/stretchr/testify/assert"
+)
+
+func loadTestUniverse(t *testing.T) *Universe {
+	namesContents, err := ioutil.ReadFile("../../test/testdata/invNames.yaml")
+	if err != nil {
+		t.Fatalf("Failed to read invNames YAML test data: %v", err)
+	}
+	names, err := ImportNames(namesContents)
+	assert.Nil(t, err)
+
+	universe, err := LoadUniverse("../../test/testdata/universe", names)
+	if err != nil {
+		t.Fatalf("Failed to load universe test data: %v", err)
+	}
+	return universe
+}
+
+func TestLoadUniverse(t *testing.T) {
+	universe := loadTestUniverse(t)
+	assert.Len(t, universe.Regions, 1)
+	assert.Len(t, universe.Constellations, 1)
+	assert.Len(t, universe.Systems, 3)
+	assert.Len(t, universe.Stargates, 6)
+
+	forge := universe.Regions[10000002]
+	assert.Equal(t, "The Forge", forge.Name)
+	assert.Equal(t, []eveonline.ConstellationID{20000020}, forge.ConstellationIDs)
+
+	kimotoro := universe.Constellations[20000020]
+	assert.Equal(t, "Kimotoro", kimotoro.Name)
+	assert.Equal(t, eveonline.RegionID(10000002), kimotoro.RegionID)
+	assert.Equal(t, []eveonline.SystemID{30000142, 30000144, 30000145}, kimotoro.SystemIDs)
+
+	jita := universe.Systems[30000142]
+	assert.Equal(t, "Jita", jita.Name)
+	assert.InDelta(t, 0.9459, jita.Security, 0.0001)
+	assert.Equal(t, eveonline.RegionID(10000002), jita.RegionID)
+	assert.Equal(t, []eveonline.StationID{60003760}, jita.StationIDs)
+
+	// New Caldari is missing from the names fixture.
+	assert.Equal(t, "NewCaldari", universe.Systems[30000145].Name)
+}
+
+func TestUniverseGraph(t *testing.T) {
+	universe := loadTestUniverse(t)
+
+	assert.Equal(t, []eveonline.SystemID{30000144, 30000145}, universe.Neighbours(30000142))
+	assert.Equal(t, eveonline.SystemID(30000144), universe.Stargates[50001248].DestinationSystemID)
+
+	perimeter, ok := universe.SystemByName(" perimeter ")
+	assert.True(t, ok)
+	assert.Equal(t, eveonline.SystemID(30000144), perimeter.ID)
+	_, ok = universe.SystemByName("Amarr")
+	assert.False(t, ok)
+
+	contents, err := ioutil.ReadFile("../../test/testdata/staStations.yaml")
+	if err != nil {
+		t.Fatalf("Failed to read staStations YAML test data: %v", err)
+	}
+	stations, err := ImportStations(contents)
+	assert.Nil(t, err)
+	universe.AddStations(stations)
+
+	location, ok := universe.LocateStation(60003760)
+	assert.True(t, ok)
+	assert.Equal(t, "Jita", location.System.Name)
+	assert.Equal(t, "Kimotoro", location.Constellation.Name)
+	assert.Equal(t, "The Forge", location.Region.Name)
+
+	// Amarr is in staStations but not in the loaded map.
+	_, ok = universe.LocateStation(60008494)
+	assert.False(t, ok)
+}
+
+func TestUniverseLinkSkipsDanglingStargates(t *testing.T) {
+	universe := loadTestUniverse(t)
+	assert.Empty(t, universe.DanglingStargateIDs)
+
+	// A gate out of the loaded region, as when only The Forge is loaded.
+	jita := universe.Systems[30000142]
+	jita.Stargates[50000056] = &Stargate{ID: 50000056, DestinationID: 50000057, SystemID: 30000142}
+	universe.Stargates[50000056] = jita.Stargates[50000056]
+	universe.Link()
+
+	assert.Equal(t, []eveonline.StargateID{50000056}, universe.DanglingStargateIDs)
+	assert.Equal(t, []eveonline.SystemID{30000144, 30000145}, universe.Neighbours(30000142))
+}
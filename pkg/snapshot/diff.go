@@ -0,0 +1,120 @@
+package snapshot
+
+import (
+	"sort"
+
+	"github.com/pequalsnp/go-eveonline/pkg/esi"
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+)
+
+type RemovalReason string
+
+const (
+	Expired RemovalReason = "expired"
+	// Filled and Cancelled are estimates, ESI does not say why an order went away.  An order that
+	// was the best price at its location is assumed filled, anything else cancelled.
+	Filled    RemovalReason = "filled"
+	Cancelled RemovalReason = "cancelled"
+)
+
+type RemovedOrder struct {
+	Order  *esi.Order
+	Reason RemovalReason
+}
+
+type PriceChange struct {
+	Previous *esi.Order
+	Current  *esi.Order
+}
+
+// TradedVolume is the estimated volume traded against sell orders, bought by someone, and
+// against buy orders, sold by someone.
+type TradedVolume struct {
+	FromSellOrders int64
+	FromBuyOrders  int64
+}
+
+type Diff struct {
+	New          []*esi.Order
+	Removed      []*RemovedOrder
+	PriceChanges []*PriceChange
+	Traded       map[eveonline.TypeID]*TradedVolume
+}
+
+type bestPriceKey struct {
+	typeID     eveonline.TypeID
+	locationID eveonline.LocationID
+	isBuy      bool
+}
+
+// Compare diffs two consecutive snapshots of the same region.  Traded volume counts partial fills
+// of orders in both snapshots plus the remaining volume of orders estimated to be filled.  Results
+// are sorted by order id.
+func Compare(previous *esi.Orders, current *esi.Orders) *Diff {
+	diff := &Diff{
+		New:          make([]*esi.Order, 0),
+		Removed:      make([]*RemovedOrder, 0),
+		PriceChanges: make([]*PriceChange, 0),
+		Traded:       make(map[eveonline.TypeID]*TradedVolume),
+	}
+
+	previousByID := make(map[int64]*esi.Order, len(previous.Orders))
+	best := make(map[bestPriceKey]float64)
+	for _, order := range previous.Orders {
+		previousByID[order.ID] = order
+		key := bestPriceKey{typeID: order.TypeID, locationID: order.LocationID, isBuy: order.IsBuy}
+		price, ok := best[key]
+		if !ok || (order.IsBuy && order.Price > price) || (!order.IsBuy && order.Price < price) {
+			best[key] = order.Price
+		}
+	}
+
+	currentByID := make(map[int64]*esi.Order, len(current.Orders))
+	for _, order := range current.Orders {
+		currentByID[order.ID] = order
+		before, ok := previousByID[order.ID]
+		if !ok {
+			diff.New = append(diff.New, order)
+			continue
+		}
+		if order.Price != before.Price {
+			diff.PriceChanges = append(diff.PriceChanges, &PriceChange{Previous: before, Current: order})
+		}
+		if traded := before.VolumeRemain - order.VolumeRemain; traded > 0 {
+			diff.addTraded(order, traded)
+		}
+	}
+
+	for _, order := range previous.Orders {
+		if _, ok := currentByID[order.ID]; ok {
+			continue
+		}
+		removed := &RemovedOrder{Order: order, Reason: Cancelled}
+		key := bestPriceKey{typeID: order.TypeID, locationID: order.LocationID, isBuy: order.IsBuy}
+		if !current.ExpiresAt.IsZero() && !order.ExpiresAt().After(current.ExpiresAt) {
+			removed.Reason = Expired
+		} else if best[key] == order.Price {
+			removed.Reason = Filled
+			diff.addTraded(order, order.VolumeRemain)
+		}
+		diff.Removed = append(diff.Removed, removed)
+	}
+
+	sort.Slice(diff.New, func(i, j int) bool { return diff.New[i].ID < diff.New[j].ID })
+	sort.Slice(diff.Removed, func(i, j int) bool { return diff.Removed[i].Order.ID < diff.Removed[j].Order.ID })
+	sort.Slice(diff.PriceChanges, func(i, j int) bool { return diff.PriceChanges[i].Current.ID < diff.PriceChanges[j].Current.ID })
+	return diff
+}
+
+func (d *Diff) addTraded(order *esi.Order, volume int64) {
+	traded, ok := d.Traded[order.TypeID]
+	if !ok {
+		traded = &TradedVolume{}
+		d.Traded[order.TypeID] = traded
+	}
+	if order.IsBuy {
+		traded.FromBuyOrders += volume
+	} else {
+		traded.FromSellOrders += volume
+	}
+}
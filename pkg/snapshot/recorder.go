@@ -0,0 +1,100 @@
+package snapshot
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pequalsnp/go-eveonline/pkg/esi"
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+)
+
+// OrdersSource is satisfied by *esi.ESI and by Replay.
+type OrdersSource interface {
+	GetOrders(
+		regionID eveonline.RegionID,
+		locationID *eveonline.LocationID,
+		httpClient *http.Client,
+		onlyForTypeID *eveonline.TypeID,
+	) (*esi.Orders, error)
+}
+
+var _ OrdersSource = (*esi.ESI)(nil)
+
+// RecordDelay is how long after the cache expires the recorder asks again, so it does not get
+// the old response back.
+const RecordDelay = 5 * time.Second
+
+// minimumRecordWait stops a missing or past expiry from turning the recorder into a busy loop.
+const minimumRecordWait = 30 * time.Second
+
+// Recorder saves a region's order book every time the ESI cache for it expires.
+type Recorder struct {
+	Source     OrdersSource
+	Store      *Store
+	RegionID   eveonline.RegionID
+	HttpClient *http.Client
+
+	lastExpiresAt time.Time
+	now           func() time.Time
+	sleep         func(d time.Duration, stop <-chan struct{}) bool
+}
+
+func NewRecorder(source OrdersSource, store *Store, regionID eveonline.RegionID, httpClient *http.Client) *Recorder {
+	return &Recorder{
+		Source:     source,
+		Store:      store,
+		RegionID:   regionID,
+		HttpClient: httpClient,
+		now:        time.Now,
+		sleep:      sleep,
+	}
+}
+
+func sleep(d time.Duration, stop <-chan struct{}) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-stop:
+		return false
+	}
+}
+
+// RecordOnce fetches the order book and saves it unless it is the response already saved, which
+// happens when the cache has not expired yet.  The path is empty when nothing was saved.
+func (r *Recorder) RecordOnce() (*esi.Orders, string, error) {
+	orders, err := r.Source.GetOrders(r.RegionID, nil, r.HttpClient, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("Failed to get orders for region %d, %v", r.RegionID, err)
+	}
+	if !orders.ExpiresAt.IsZero() && orders.ExpiresAt.Equal(r.lastExpiresAt) {
+		return orders, "", nil
+	}
+
+	path, err := r.Store.Save(orders)
+	if err != nil {
+		return nil, "", err
+	}
+	r.lastExpiresAt = orders.ExpiresAt
+	return orders, path, nil
+}
+
+// Record keeps recording until stop is closed or a fetch fails.
+func (r *Recorder) Record(stop <-chan struct{}) error {
+	for {
+		orders, _, err := r.RecordOnce()
+		if err != nil {
+			return err
+		}
+
+		wait := orders.ExpiresAt.Sub(r.now()) + RecordDelay
+		if wait < minimumRecordWait {
+			wait = minimumRecordWait
+		}
+		if !r.sleep(wait, stop) {
+			return nil
+		}
+	}
+}
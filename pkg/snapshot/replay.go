@@ -0,0 +1,89 @@
+package snapshot
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/pequalsnp/go-eveonline/pkg/esi"
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+)
+
+// Replay serves recorded snapshots in order.  It satisfies OrdersSource, so code written against
+// GetOrders can be run against a recording.
+type Replay struct {
+	snapshots []*esi.Orders
+	paths     []string
+	store     *Store
+	next      int
+}
+
+// NewReplay replays a region's snapshots from a store, loading each one as it is reached.
+func NewReplay(store *Store, regionID eveonline.RegionID) (*Replay, error) {
+	paths, err := store.List(regionID)
+	if err != nil {
+		return nil, err
+	}
+	return &Replay{paths: paths, store: store}, nil
+}
+
+// NewReplayFromOrders replays snapshots held in memory.
+func NewReplayFromOrders(snapshots ...*esi.Orders) *Replay {
+	return &Replay{snapshots: snapshots}
+}
+
+func (r *Replay) Len() int {
+	if r.store != nil {
+		return len(r.paths)
+	}
+	return len(r.snapshots)
+}
+
+// Next returns the next snapshot, or false once all have been replayed.
+func (r *Replay) Next() (*esi.Orders, bool, error) {
+	if r.next >= r.Len() {
+		return nil, false, nil
+	}
+
+	index := r.next
+	r.next++
+	if r.store == nil {
+		return r.snapshots[index], true, nil
+	}
+	orders, err := r.store.Load(r.paths[index])
+	if err != nil {
+		return nil, false, err
+	}
+	return orders, true, nil
+}
+
+// GetOrders returns the next snapshot, filtered like esi.ESI.GetOrders.  The region must match the
+// recording.
+func (r *Replay) GetOrders(
+	regionID eveonline.RegionID,
+	locationID *eveonline.LocationID,
+	httpClient *http.Client,
+	onlyForTypeID *eveonline.TypeID,
+) (*esi.Orders, error) {
+	orders, ok, err := r.Next()
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("Replay has no snapshots left")
+	}
+	if orders.RegionID != regionID {
+		return nil, fmt.Errorf("Replay recorded region %d, not %d", orders.RegionID, regionID)
+	}
+
+	filtered := &esi.Orders{RegionID: orders.RegionID, ExpiresAt: orders.ExpiresAt, Orders: make([]*esi.Order, 0, len(orders.Orders))}
+	for _, order := range orders.Orders {
+		if locationID != nil && order.LocationID != *locationID {
+			continue
+		}
+		if onlyForTypeID != nil && order.TypeID != *onlyForTypeID {
+			continue
+		}
+		filtered.Orders = append(filtered.Orders, order)
+	}
+	return filtered, nil
+}
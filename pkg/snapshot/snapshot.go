@@ -0,0 +1,106 @@
+package snapshot
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pequalsnp/go-eveonline/pkg/esi"
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+)
+
+const fileSuffix = ".json.gz"
+const fileTimeFormat = "20060102T150405Z"
+
+func Write(w io.Writer, orders *esi.Orders) error {
+	gzipWriter := gzip.NewWriter(w)
+	err := json.NewEncoder(gzipWriter).Encode(orders)
+	if err != nil {
+		gzipWriter.Close()
+		return fmt.Errorf("Failed to encode orders snapshot, %v", err)
+	}
+	return gzipWriter.Close()
+}
+
+func Read(r io.Reader) (*esi.Orders, error) {
+	gzipReader, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to open orders snapshot, %v", err)
+	}
+	defer gzipReader.Close()
+
+	orders := new(esi.Orders)
+	err = json.NewDecoder(gzipReader).Decode(orders)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to decode orders snapshot, %v", err)
+	}
+	return orders, nil
+}
+
+// Store keeps gzipped JSON snapshots in a directory, one file per region and cache expiry named
+// so that a region's files sort by time.
+type Store struct {
+	Dir string
+}
+
+func (s *Store) path(regionID eveonline.RegionID, expiresAt time.Time) string {
+	return filepath.Join(s.Dir, fmt.Sprintf("%d-%s%s", regionID, expiresAt.UTC().Format(fileTimeFormat), fileSuffix))
+}
+
+// Save writes a snapshot and returns its path.  Saving a snapshot with the same region and
+// expiry again replaces the file.
+func (s *Store) Save(orders *esi.Orders) (string, error) {
+	path := s.path(orders.RegionID, orders.ExpiresAt)
+	temporary, err := ioutil.TempFile(s.Dir, ".snapshot-")
+	if err != nil {
+		return "", fmt.Errorf("Failed to create orders snapshot, %v", err)
+	}
+	err = Write(temporary, orders)
+	if closeErr := temporary.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(temporary.Name(), path)
+	}
+	if err != nil {
+		os.Remove(temporary.Name())
+		return "", fmt.Errorf("Failed to save orders snapshot %s, %v", path, err)
+	}
+	return path, nil
+}
+
+func (s *Store) Load(path string) (*esi.Orders, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to open orders snapshot %s, %v", path, err)
+	}
+	defer file.Close()
+	return Read(file)
+}
+
+// List returns the paths of a region's snapshots, oldest first.
+func (s *Store) List(regionID eveonline.RegionID) ([]string, error) {
+	entries, err := ioutil.ReadDir(s.Dir)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to list orders snapshots, %v", err)
+	}
+
+	prefix := fmt.Sprintf("%d-", regionID)
+	paths := make([]string, 0)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, fileSuffix) {
+			continue
+		}
+		paths = append(paths, filepath.Join(s.Dir, name))
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
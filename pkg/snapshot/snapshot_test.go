@@ -0,0 +1,96 @@
+package snapshot
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/pequalsnp/go-eveonline/pkg/esi"
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+	"github.com/stretchr/testify/assert"
+)
+
+var issued = time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+func testSnapshots() (*esi.Orders, *esi.Orders) {
+	previous := &esi.Orders{RegionID: 10000002, ExpiresAt: time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC), Orders: []*esi.Order{
+		{ID: 1, TypeID: 34, Price: 5.0, VolumeRemain: 100, LocationID: 60003760, Duration: 90, Issued: issued},
+		{ID: 2, TypeID: 34, Price: 5.5, VolumeRemain: 200, LocationID: 60003760, Duration: 90, Issued: issued},
+		{ID: 3, TypeID: 34, Price: 4.0, VolumeRemain: 300, IsBuy: true, LocationID: 60003760, Duration: 90, Issued: issued},
+		{ID: 4, TypeID: 34, Price: 3.0, VolumeRemain: 50, IsBuy: true, LocationID: 60003760, Duration: 90, Issued: issued},
+		{ID: 5, TypeID: 35, Price: 9.0, VolumeRemain: 10, LocationID: 60003760, Duration: 18, Issued: issued},
+		{ID: 6, TypeID: 35, Price: 10.0, VolumeRemain: 10, LocationID: 60003760, Duration: 90, Issued: issued},
+	}}
+	current := &esi.Orders{RegionID: 10000002, ExpiresAt: previous.ExpiresAt.Add(5 * time.Minute), Orders: []*esi.Order{
+		{ID: 2, TypeID: 34, Price: 4.99, VolumeRemain: 200, LocationID: 60003760, Duration: 90, Issued: issued.Add(time.Hour)},
+		{ID: 3, TypeID: 34, Price: 4.0, VolumeRemain: 120, IsBuy: true, LocationID: 60003760, Duration: 90, Issued: issued},
+		{ID: 6, TypeID: 35, Price: 10.0, VolumeRemain: 10, LocationID: 60003760, Duration: 90, Issued: issued},
+		{ID: 7, TypeID: 35, Price: 9.5, VolumeRemain: 40, LocationID: 60003760, Duration: 90, Issued: issued.Add(time.Hour)},
+	}}
+	return previous, current
+}
+
+func TestCompare(t *testing.T) {
+	previous, current := testSnapshots()
+	diff := Compare(previous, current)
+
+	assert.Len(t, diff.New, 1)
+	assert.Equal(t, int64(7), diff.New[0].ID)
+
+	reasons := make(map[int64]RemovalReason)
+	for _, removed := range diff.Removed {
+		reasons[removed.Order.ID] = removed.Reason
+	}
+	assert.Equal(t, map[int64]RemovalReason{1: Filled, 4: Cancelled, 5: Expired}, reasons)
+
+	assert.Len(t, diff.PriceChanges, 1)
+	assert.Equal(t, 5.5, diff.PriceChanges[0].Previous.Price)
+	assert.Equal(t, 4.99, diff.PriceChanges[0].Current.Price)
+
+	assert.Equal(t, map[eveonline.TypeID]*TradedVolume{34: {FromSellOrders: 100, FromBuyOrders: 180}}, diff.Traded)
+}
+
+func TestStoreAndRecorder(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshots")
+	if err != nil {
+		t.Fatalf("Failed to create snapshot directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	store := &Store{Dir: dir}
+
+	previous, current := testSnapshots()
+	recorder := NewRecorder(NewReplayFromOrders(previous, previous, current), store, 10000002, nil)
+	now := previous.ExpiresAt.Add(-time.Minute)
+	recorder.now = func() time.Time { return now }
+	waits := make([]time.Duration, 0)
+	recorder.sleep = func(d time.Duration, stop <-chan struct{}) bool {
+		waits = append(waits, d)
+		now = now.Add(d)
+		return len(waits) < 3
+	}
+
+	assert.Nil(t, recorder.Record(nil))
+	assert.Equal(t, []time.Duration{time.Minute + RecordDelay, minimumRecordWait, 5*time.Minute - minimumRecordWait}, waits)
+
+	paths, err := store.List(10000002)
+	assert.Nil(t, err)
+	assert.Len(t, paths, 2)
+
+	replay, err := NewReplay(store, 10000002)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, replay.Len())
+	loaded, err := replay.GetOrders(10000002, nil, nil, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, previous.ExpiresAt.Unix(), loaded.ExpiresAt.Unix())
+	assert.Len(t, loaded.Orders, len(previous.Orders))
+	assert.Equal(t, previous.Orders[0].Issued.Unix(), loaded.Orders[0].Issued.Unix())
+
+	typeID := eveonline.TypeID(35)
+	loaded, err = replay.GetOrders(10000002, nil, nil, &typeID)
+	assert.Nil(t, err)
+	assert.Len(t, loaded.Orders, 2)
+
+	_, err = replay.GetOrders(10000002, nil, nil, nil)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,240 @@
+package trade
+
+import (
+	"math"
+	"sort"
+
+	"github.com/pequalsnp/go-eveonline/pkg/esi"
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+)
+
+// Hub is a trading location together with the order book covering it, either fresh from
+// GetOrders or a recorded snapshot.  Hubs in the same region can share one Orders.
+type Hub struct {
+	Name       string
+	LocationID eveonline.LocationID
+	SystemID   eveonline.SystemID
+	Orders     *esi.Orders
+}
+
+type TradeParameters struct {
+	CargoCapacity float64
+	Budget        float64
+	SalesTax      float64
+	BrokerFee     float64
+	// SellToBuyOrders sells into the destination's buy orders right away.  Otherwise the cargo
+	// is listed one tick below the destination's lowest sell order, paying the broker fee.
+	SellToBuyOrders bool
+	MinProfit       float64
+}
+
+type Opportunity struct {
+	TypeID        eveonline.TypeID
+	From          *Hub
+	To            *Hub
+	Quantity      int64
+	Cost          float64
+	Revenue       float64
+	Profit        float64
+	CargoVolume   float64
+	Jumps         int
+	ProfitPerJump float64
+}
+
+// FindArbitrage looks for buy-here-sell-there trades between every ordered pair of hubs.  Each
+// opportunity walks the order books level by level, so a thin top of book does not inflate the
+// profit, and is limited on its own by cargo and budget.  jumps comes from
+// navigation.Router.JumpMatrix; pairs without an entry are skipped.  It also decides which buy
+// orders with a jump range reach a hub from another system, so include the systems of those
+// orders to sell to them.  volumes maps types to their packaged volume, and types without a
+// volume are skipped.  Results are sorted by profit.
+func FindArbitrage(
+	hubs []*Hub,
+	jumps map[eveonline.SystemID]map[eveonline.SystemID]int,
+	volumes map[eveonline.TypeID]float64,
+	parameters TradeParameters,
+) []*Opportunity {
+	opportunities := make([]*Opportunity, 0)
+	reachable := jumpsFunc(jumps)
+	for _, from := range hubs {
+		for _, to := range hubs {
+			if from == to {
+				continue
+			}
+			jumpCount, ok := jumps[from.SystemID][to.SystemID]
+			if !ok {
+				continue
+			}
+			for _, typeID := range sellingTypes(from) {
+				volume, ok := volumes[typeID]
+				if !ok {
+					continue
+				}
+				opportunity := findTrade(from, to, typeID, volume, reachable, parameters)
+				if opportunity == nil || opportunity.Profit < parameters.MinProfit {
+					continue
+				}
+				opportunity.Jumps = jumpCount
+				opportunity.ProfitPerJump = opportunity.Profit / math.Max(1, float64(jumpCount))
+				opportunities = append(opportunities, opportunity)
+			}
+		}
+	}
+
+	sort.SliceStable(opportunities, func(i, j int) bool {
+		if opportunities[i].Profit != opportunities[j].Profit {
+			return opportunities[i].Profit > opportunities[j].Profit
+		}
+		return opportunities[i].TypeID < opportunities[j].TypeID
+	})
+	return opportunities
+}
+
+func sellingTypes(hub *Hub) []eveonline.TypeID {
+	seen := make(map[eveonline.TypeID]bool)
+	typeIDs := make([]eveonline.TypeID, 0)
+	for _, order := range hub.Orders.Orders {
+		if order.IsBuy || order.VolumeRemain <= 0 || order.LocationID != hub.LocationID || seen[order.TypeID] {
+			continue
+		}
+		seen[order.TypeID] = true
+		typeIDs = append(typeIDs, order.TypeID)
+	}
+	sort.Slice(typeIDs, func(i, j int) bool { return typeIDs[i] < typeIDs[j] })
+	return typeIDs
+}
+
+// jumpsFunc adapts a jump matrix to esi.BuyOrderReaches.
+func jumpsFunc(jumps map[eveonline.SystemID]map[eveonline.SystemID]int) func(from eveonline.SystemID, to eveonline.SystemID) (int, bool) {
+	return func(from eveonline.SystemID, to eveonline.SystemID) (int, bool) {
+		jumpCount, ok := jumps[from][to]
+		return jumpCount, ok
+	}
+}
+
+func findTrade(
+	from *Hub,
+	to *Hub,
+	typeID eveonline.TypeID,
+	volume float64,
+	jumps func(from eveonline.SystemID, to eveonline.SystemID) (int, bool),
+	parameters TradeParameters,
+) *Opportunity {
+	asks := from.Orders.Depth(typeID, false, esi.AtLocation(from.LocationID))
+
+	// Each level is a price we can sell at after fees and the units available there.  Listed
+	// sales have no depth limit.
+	var bids []esi.PriceLevel
+	if parameters.SellToBuyOrders {
+		reaches := esi.BuyOrderReaches(to.LocationID, to.SystemID, jumps)
+		// Buy orders with a minimum volume are left out rather than guessing how we would split.
+		bids = to.Orders.Depth(typeID, true, func(order *esi.Order) bool {
+			return order.MinVolume <= 1 && reaches(order)
+		})
+		for i := range bids {
+			bids[i].Price *= 1.0 - parameters.SalesTax
+		}
+	} else {
+		lowest, ok := to.Orders.BestAsk(typeID, esi.AtLocation(to.LocationID))
+		if !ok {
+			return nil
+		}
+		price := lowest.Price - esi.PriceTick(lowest.Price)
+		bids = []esi.PriceLevel{{Price: price * (1.0 - parameters.SalesTax - parameters.BrokerFee), Volume: math.MaxInt64}}
+	}
+
+	maxUnits := int64(math.MaxInt64)
+	if volume > 0 {
+		maxUnits = int64(math.Floor(parameters.CargoCapacity / volume))
+	}
+
+	opportunity := &Opportunity{TypeID: typeID, From: from, To: to}
+	budget := parameters.Budget
+	i, j := 0, 0
+	for i < len(asks) && j < len(bids) && opportunity.Quantity < maxUnits {
+		ask, bid := &asks[i], &bids[j]
+		if bid.Price <= ask.Price {
+			break
+		}
+		units := minInt64(ask.Volume, bid.Volume, maxUnits-opportunity.Quantity, int64(math.Floor(budget/ask.Price)))
+		if units <= 0 {
+			break
+		}
+
+		opportunity.Quantity += units
+		opportunity.Cost += float64(units) * ask.Price
+		opportunity.Revenue += float64(units) * bid.Price
+		budget -= float64(units) * ask.Price
+		ask.Volume -= units
+		bid.Volume -= units
+		if ask.Volume == 0 {
+			i++
+		}
+		if bid.Volume == 0 {
+			j++
+		}
+	}
+	if opportunity.Quantity == 0 {
+		return nil
+	}
+
+	opportunity.Profit = opportunity.Revenue - opportunity.Cost
+	opportunity.CargoVolume = float64(opportunity.Quantity) * volume
+	return opportunity
+}
+
+type StationTrade struct {
+	TypeID eveonline.TypeID
+	// BuyPrice and SellPrice are one tick inside the current best bid and ask.
+	BuyPrice      float64
+	SellPrice     float64
+	ProfitPerUnit float64
+	Margin        float64
+}
+
+// FindStationTrades prices buying and selling at the same hub with orders placed one tick inside
+// the spread.  Both orders pay the broker fee and the sale pays sales tax.  Results are sorted
+// by margin.
+func FindStationTrades(hub *Hub, parameters TradeParameters) []*StationTrade {
+	trades := make([]*StationTrade, 0)
+	at := esi.AtLocation(hub.LocationID)
+	for _, typeID := range sellingTypes(hub) {
+		bid, ok := hub.Orders.BestBid(typeID, at)
+		if !ok {
+			continue
+		}
+		ask, ok := hub.Orders.BestAsk(typeID, at)
+		if !ok {
+			continue
+		}
+
+		buyPrice := bid.Price + esi.PriceTick(bid.Price)
+		sellPrice := ask.Price - esi.PriceTick(ask.Price)
+		cost := buyPrice * (1.0 + parameters.BrokerFee)
+		revenue := sellPrice * (1.0 - parameters.SalesTax - parameters.BrokerFee)
+		if revenue-cost < parameters.MinProfit || revenue <= cost {
+			continue
+		}
+
+		trades = append(trades, &StationTrade{
+			TypeID:        typeID,
+			BuyPrice:      buyPrice,
+			SellPrice:     sellPrice,
+			ProfitPerUnit: revenue - cost,
+			Margin:        (revenue - cost) / cost,
+		})
+	}
+
+	sort.SliceStable(trades, func(i, j int) bool { return trades[i].Margin > trades[j].Margin })
+	return trades
+}
+
+func minInt64(values ...int64) int64 {
+	min := values[0]
+	for _, value := range values[1:] {
+		if value < min {
+			min = value
+		}
+	}
+	return min
+}
@@ -0,0 +1,147 @@
+package trade
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/pequalsnp/go-eveonline/pkg/esi"
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+	"github.com/stretchr/testify/assert"
+)
+
+func loadTestHubs(t *testing.T) (*Hub, *Hub) {
+	contents, err := ioutil.ReadFile("../../test/testdata/marketOrders.json")
+	if err != nil {
+		t.Fatalf("Failed to read market orders test data: %v", err)
+	}
+	orders := make([]*esi.Order, 0)
+	err = json.Unmarshal(contents, &orders)
+	if err != nil {
+		t.Fatalf("Failed to unmarshal market orders test data: %v", err)
+	}
+
+	snapshot := &esi.Orders{Orders: orders}
+	jita := &Hub{Name: "Jita", LocationID: 60003760, SystemID: 30000142, Orders: snapshot}
+	amarr := &Hub{Name: "Amarr", LocationID: 60008494, SystemID: 30002187, Orders: snapshot}
+	return jita, amarr
+}
+
+var testJumps = map[eveonline.SystemID]map[eveonline.SystemID]int{
+	30000142: {30002187: 45},
+	30002187: {30000142: 45},
+}
+
+var testVolumes = map[eveonline.TypeID]float64{34: 0.01, 587: 2500}
+
+func TestFindArbitrageSellingToBuyOrders(t *testing.T) {
+	jita, amarr := loadTestHubs(t)
+
+	opportunities := FindArbitrage([]*Hub{jita, amarr}, testJumps, testVolumes, TradeParameters{
+		CargoCapacity:   10000,
+		Budget:          1000000000,
+		SalesTax:        0.05,
+		SellToBuyOrders: true,
+	})
+	assert.Len(t, opportunities, 1)
+	tritanium := opportunities[0]
+	assert.Equal(t, eveonline.TypeID(34), tritanium.TypeID)
+	assert.Equal(t, "Jita", tritanium.From.Name)
+	assert.Equal(t, "Amarr", tritanium.To.Name)
+	assert.Equal(t, int64(2000), tritanium.Quantity)
+	assert.InDelta(t, 8100.0, tritanium.Cost, 0.0001)
+	assert.InDelta(t, 8787.5, tritanium.Revenue, 0.0001)
+	assert.InDelta(t, 687.5, tritanium.Profit, 0.0001)
+	assert.InDelta(t, 20.0, tritanium.CargoVolume, 0.0001)
+	assert.Equal(t, 45, tritanium.Jumps)
+
+	limited := FindArbitrage([]*Hub{jita, amarr}, testJumps, testVolumes, TradeParameters{
+		CargoCapacity:   5,
+		Budget:          1000000000,
+		SalesTax:        0.05,
+		SellToBuyOrders: true,
+	})
+	if assert.Len(t, limited, 1) {
+		assert.Equal(t, int64(500), limited[0].Quantity)
+	}
+
+	assert.Empty(t, FindArbitrage([]*Hub{jita, amarr}, map[eveonline.SystemID]map[eveonline.SystemID]int{}, testVolumes, TradeParameters{
+		CargoCapacity:   10000,
+		Budget:          1000000000,
+		SellToBuyOrders: true,
+	}))
+}
+
+func TestFindArbitrageRangedBuyOrders(t *testing.T) {
+	jita, amarr := loadTestHubs(t)
+	amarr.Orders.Orders = append(amarr.Orders.Orders, &esi.Order{
+		ID: 2007, TypeID: 34, Price: 4.8, VolumeRemain: 1000, MinVolume: 1, IsBuy: true,
+		LocationID: 60008495, SystemID: 30002188, Range: "2",
+	})
+	parameters := TradeParameters{CargoCapacity: 10000, Budget: 1000000000, SalesTax: 0.05, SellToBuyOrders: true}
+
+	// Without a route to its system the bid one jump from Amarr is out of reach.
+	opportunities := FindArbitrage([]*Hub{jita, amarr}, testJumps, testVolumes, parameters)
+	if assert.Len(t, opportunities, 1) {
+		assert.InDelta(t, 687.5, opportunities[0].Profit, 0.0001)
+	}
+
+	jumps := map[eveonline.SystemID]map[eveonline.SystemID]int{
+		30000142: {30002187: 45},
+		30002187: {30000142: 45},
+		30002188: {30002187: 1},
+	}
+	opportunities = FindArbitrage([]*Hub{jita, amarr}, jumps, testVolumes, parameters)
+	if assert.Len(t, opportunities, 1) {
+		tritanium := opportunities[0]
+		assert.Equal(t, int64(2000), tritanium.Quantity)
+		assert.InDelta(t, 500*4.75+1000*4.56+500*4.275, tritanium.Revenue, 0.0001)
+		assert.InDelta(t, 972.5, tritanium.Profit, 0.0001)
+	}
+}
+
+func TestFindArbitrageListing(t *testing.T) {
+	jita, amarr := loadTestHubs(t)
+
+	opportunities := FindArbitrage([]*Hub{jita, amarr}, testJumps, testVolumes, TradeParameters{
+		CargoCapacity: 5000,
+		Budget:        1000000000,
+		SalesTax:      0.05,
+		BrokerFee:     0.03,
+		MinProfit:     1000,
+	})
+	assert.Len(t, opportunities, 2)
+	rifter := opportunities[0]
+	assert.Equal(t, eveonline.TypeID(587), rifter.TypeID)
+	assert.Equal(t, int64(2), rifter.Quantity)
+	assert.InDelta(t, 2*(499900*0.92-400000), rifter.Profit, 0.0001)
+
+	budgeted := FindArbitrage([]*Hub{jita, amarr}, testJumps, testVolumes, TradeParameters{
+		CargoCapacity: 5000,
+		Budget:        500000,
+		SalesTax:      0.05,
+		BrokerFee:     0.03,
+		MinProfit:     1000,
+	})
+	if assert.NotEmpty(t, budgeted) {
+		assert.Equal(t, int64(1), budgeted[0].Quantity)
+	}
+}
+
+func TestFindStationTrades(t *testing.T) {
+	jita, _ := loadTestHubs(t)
+
+	trades := FindStationTrades(jita, TradeParameters{SalesTax: 0.05, BrokerFee: 0.03})
+	assert.Len(t, trades, 1)
+	assert.Equal(t, eveonline.TypeID(35), trades[0].TypeID)
+	assert.InDelta(t, 10.01, trades[0].BuyPrice, 0.0001)
+	assert.InDelta(t, 11.99, trades[0].SellPrice, 0.0001)
+	assert.InDelta(t, 11.99*0.92-10.01*1.03, trades[0].ProfitPerUnit, 0.0001)
+
+	// A filled sell order leaves nothing to sell against the bid.
+	jita.Orders.Orders = append(jita.Orders.Orders,
+		&esi.Order{ID: 9001, TypeID: 36, Price: 20.0, VolumeRemain: 0, LocationID: 60003760, SystemID: 30000142, Range: esi.RegionOrderRange},
+		&esi.Order{ID: 9002, TypeID: 36, Price: 10.0, VolumeRemain: 1000, IsBuy: true, LocationID: 60003760, SystemID: 30000142, Range: esi.StationOrderRange},
+	)
+	assert.Len(t, FindStationTrades(jita, TradeParameters{SalesTax: 0.05, BrokerFee: 0.03}), 1)
+}
@@ -0,0 +1,120 @@
+package trade
+
+import (
+	"math"
+
+	"github.com/pequalsnp/go-eveonline/pkg/esi"
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+)
+
+const (
+	AccountingSkillID              = eveonline.SkillID(16622)
+	BrokerRelationsSkillID         = eveonline.SkillID(3446)
+	AdvancedBrokerRelationsSkillID = eveonline.SkillID(3447)
+)
+
+const (
+	DefaultSalesTaxRate          = 0.075
+	accountingReduction          = 0.11
+	npcBaseBrokerFee             = 0.03
+	brokerRelationsReduction     = 0.003
+	factionStandingReduction     = 0.0003
+	corporationStandingReduction = 0.0002
+	relistBaseDiscount           = 0.5
+	advancedBrokerDiscount       = 0.06
+)
+
+type MarketSkills struct {
+	Accounting              int
+	BrokerRelations         int
+	AdvancedBrokerRelations int
+}
+
+func MarketSkillsFromESI(characterSkills *esi.CharacterSkills) MarketSkills {
+	level := func(skillID eveonline.SkillID) int {
+		if skill, ok := characterSkills.Skills[skillID]; ok {
+			return skill.ActiveLevel
+		}
+		return 0
+	}
+	return MarketSkills{
+		Accounting:              level(AccountingSkillID),
+		BrokerRelations:         level(BrokerRelationsSkillID),
+		AdvancedBrokerRelations: level(AdvancedBrokerRelationsSkillID),
+	}
+}
+
+// Standings holds a character's effective standings towards factions and NPC corporations, keyed
+// by their id.  Skills that modify standings are expected to be applied already.
+type Standings map[int64]float64
+
+func StandingsFromESI(standings []*esi.Standing) Standings {
+	result := make(Standings)
+	for _, standing := range standings {
+		if standing.FromType == esi.FactionStanding || standing.FromType == esi.NPCCorporationStanding {
+			result[standing.FromID] = standing.Standing
+		}
+	}
+	return result
+}
+
+// MarketLocation describes who collects the broker fee.  NPC stations charge by skills and
+// standings towards the owning corporation and its faction; Upwell structures charge the rate
+// their owner set, which skills and standings do not change.
+type MarketLocation struct {
+	OwnerCorporationID eveonline.CorporationID
+	OwnerFactionID     eveonline.FactionID
+	IsStructure        bool
+	StructureBrokerFee float64
+}
+
+func NPCStationMarket(ownerCorporationID eveonline.CorporationID, ownerFactionID eveonline.FactionID) MarketLocation {
+	return MarketLocation{OwnerCorporationID: ownerCorporationID, OwnerFactionID: ownerFactionID}
+}
+
+func StructureMarket(brokerFee float64) MarketLocation {
+	return MarketLocation{IsStructure: true, StructureBrokerFee: brokerFee}
+}
+
+type MarketFees struct {
+	BrokerFee float64
+	SalesTax  float64
+	// RelistDiscount is the share of the broker fee waived when an order's price is modified.
+	RelistDiscount float64
+}
+
+func CalculateMarketFees(skills MarketSkills, standings Standings, location MarketLocation) MarketFees {
+	fees := MarketFees{
+		SalesTax:       DefaultSalesTaxRate * (1.0 - accountingReduction*float64(skills.Accounting)),
+		RelistDiscount: relistBaseDiscount + advancedBrokerDiscount*float64(skills.AdvancedBrokerRelations),
+	}
+
+	if location.IsStructure {
+		fees.BrokerFee = location.StructureBrokerFee
+	} else {
+		fees.BrokerFee = npcBaseBrokerFee -
+			brokerRelationsReduction*float64(skills.BrokerRelations) -
+			factionStandingReduction*standings[int64(location.OwnerFactionID)] -
+			corporationStandingReduction*standings[int64(location.OwnerCorporationID)]
+		fees.BrokerFee = math.Max(0.0, fees.BrokerFee)
+	}
+
+	return fees
+}
+
+// RelistCost is the fee for moving an order with volume units left from oldPrice to newPrice.
+// The whole new order value pays the discounted broker fee, and any price increase also pays the
+// full broker fee on the difference.
+func (f MarketFees) RelistCost(oldPrice float64, newPrice float64, volume int64) float64 {
+	cost := f.BrokerFee * newPrice * float64(volume) * (1.0 - f.RelistDiscount)
+	if newPrice > oldPrice {
+		cost += f.BrokerFee * (newPrice - oldPrice) * float64(volume)
+	}
+	return cost
+}
+
+// Apply copies the fees into trade parameters for FindArbitrage and FindStationTrades.
+func (f MarketFees) Apply(parameters *TradeParameters) {
+	parameters.BrokerFee = f.BrokerFee
+	parameters.SalesTax = f.SalesTax
+}
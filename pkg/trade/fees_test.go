@@ -0,0 +1,102 @@
+package trade
+
+import (
+	"testing"
+
+	"github.com/pequalsnp/go-eveonline/pkg/esi"
+	"github.com/pequalsnp/go-eveonline/pkg/eveonline"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalculateMarketFees(t *testing.T) {
+	// Jita 4-4 belongs to Caldari Navy, part of the Caldari State.
+	jita := NPCStationMarket(1000035, 500001)
+	standings := Standings{500001: 5.0, 1000035: 8.0}
+
+	tests := []struct {
+		name      string
+		skills    MarketSkills
+		standings Standings
+		location  MarketLocation
+		expected  MarketFees
+	}{
+		{
+			name:     "untrained",
+			location: jita,
+			expected: MarketFees{BrokerFee: 0.03, SalesTax: 0.075, RelistDiscount: 0.5},
+		},
+		{
+			name:     "maxed skills",
+			skills:   MarketSkills{Accounting: 5, BrokerRelations: 5, AdvancedBrokerRelations: 5},
+			location: jita,
+			expected: MarketFees{BrokerFee: 0.015, SalesTax: 0.03375, RelistDiscount: 0.8},
+		},
+		{
+			name:      "maxed skills with standings",
+			skills:    MarketSkills{Accounting: 5, BrokerRelations: 5, AdvancedBrokerRelations: 5},
+			standings: standings,
+			location:  jita,
+			expected:  MarketFees{BrokerFee: 0.015 - 0.0015 - 0.0016, SalesTax: 0.03375, RelistDiscount: 0.8},
+		},
+		{
+			name:      "negative standings",
+			skills:    MarketSkills{BrokerRelations: 4},
+			standings: Standings{500001: -2.0},
+			location:  jita,
+			expected:  MarketFees{BrokerFee: 0.03 - 0.012 + 0.0006, SalesTax: 0.075, RelistDiscount: 0.5},
+		},
+		{
+			name:      "structure ignores broker skills",
+			skills:    MarketSkills{Accounting: 3, BrokerRelations: 5},
+			standings: standings,
+			location:  StructureMarket(0.01),
+			expected:  MarketFees{BrokerFee: 0.01, SalesTax: 0.075 * 0.67, RelistDiscount: 0.5},
+		},
+	}
+
+	for _, test := range tests {
+		fees := CalculateMarketFees(test.skills, test.standings, test.location)
+		assert.InDelta(t, test.expected.BrokerFee, fees.BrokerFee, 0.0000001, test.name)
+		assert.InDelta(t, test.expected.SalesTax, fees.SalesTax, 0.0000001, test.name)
+		assert.InDelta(t, test.expected.RelistDiscount, fees.RelistDiscount, 0.0000001, test.name)
+	}
+}
+
+func TestRelistCost(t *testing.T) {
+	fees := MarketFees{BrokerFee: 0.02, SalesTax: 0.05, RelistDiscount: 0.8}
+
+	tests := []struct {
+		name     string
+		oldPrice float64
+		newPrice float64
+		volume   int64
+		expected float64
+	}{
+		{name: "price cut", oldPrice: 100, newPrice: 99, volume: 10, expected: 0.02 * 990 * 0.2},
+		{name: "price raise", oldPrice: 100, newPrice: 110, volume: 10, expected: 0.02*1100*0.2 + 0.02*100},
+		{name: "nothing left", oldPrice: 100, newPrice: 90, volume: 0, expected: 0},
+	}
+
+	for _, test := range tests {
+		assert.InDelta(t, test.expected, fees.RelistCost(test.oldPrice, test.newPrice, test.volume), 0.0000001, test.name)
+	}
+}
+
+func TestFeesFromESI(t *testing.T) {
+	skills := MarketSkillsFromESI(&esi.CharacterSkills{Skills: map[eveonline.SkillID]*esi.Skill{
+		AccountingSkillID:      {ID: AccountingSkillID, ActiveLevel: 4, TrainedLevel: 5},
+		BrokerRelationsSkillID: {ID: BrokerRelationsSkillID, ActiveLevel: 3},
+	}})
+	assert.Equal(t, MarketSkills{Accounting: 4, BrokerRelations: 3}, skills)
+
+	standings := StandingsFromESI([]*esi.Standing{
+		{FromID: 500001, FromType: esi.FactionStanding, Standing: 2.5},
+		{FromID: 3008416, FromType: esi.AgentStanding, Standing: 9.0},
+	})
+	assert.Equal(t, Standings{500001: 2.5}, standings)
+
+	parameters := TradeParameters{}
+	CalculateMarketFees(skills, standings, NPCStationMarket(1000035, 500001)).Apply(&parameters)
+	assert.InDelta(t, 0.03-0.009-0.00075, parameters.BrokerFee, 0.0000001)
+	assert.InDelta(t, 0.075*0.56, parameters.SalesTax, 0.0000001)
+}